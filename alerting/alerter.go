@@ -0,0 +1,207 @@
+// Package alerting evaluates the alerting rules of a ModelMonitor on the drift and outlier results written by the
+// Monitoring job to its Kafka sinks, and notifies the alerts to the receivers.
+//
+// Results are expected as JSON objects, or arrays of objects, of the form:
+//
+//	{"window": {"start": "2020-06-01T10:00:00Z", "end": "2020-06-01T10:01:00Z"},
+//	 "feature": "sepal_length", "detector": "wasserstein", "value": 0.42}
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/Shopify/sarama"
+	"github.com/go-logr/logr"
+)
+
+// Alerter notifies the alerts of the rules evaluated on the results. Results can be handled concurrently.
+type Alerter struct {
+	Evaluator *Evaluator
+	// Receivers by name
+	Receivers map[string]Receiver
+	Log       logr.Logger
+
+	mu sync.Mutex
+}
+
+// Handle evaluates the rules on a message of a source, with a result or an array of results, and notifies the alerts
+func (a *Alerter) Handle(ctx context.Context, source monitoringv1beta1.AlertSource, payload []byte) error {
+	alerts, err := a.evaluate(source, payload)
+	if err != nil {
+		return err
+	}
+	a.notify(ctx, alerts)
+	return nil
+}
+
+// Restore evaluates the rules on a message of a source without notifying the alerts, to restore the state of the
+// alerts notified before a restart
+func (a *Alerter) Restore(source monitoringv1beta1.AlertSource, payload []byte) error {
+	_, err := a.evaluate(source, payload)
+	return err
+}
+
+func (a *Alerter) evaluate(source monitoringv1beta1.AlertSource, payload []byte) ([]Alert, error) {
+	var results []Result
+	if trimmed := bytes.TrimSpace(payload); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &results); err != nil {
+			return nil, err
+		}
+	} else {
+		var result Result
+		if err := json.Unmarshal(trimmed, &result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	var alerts []Alert
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := range results {
+		alerts = append(alerts, a.Evaluator.Evaluate(source, &results[i])...)
+	}
+	return alerts, nil
+}
+
+// notify sends the alerts to their receivers, grouped by receiver. Failing receivers are logged, not retried.
+func (a *Alerter) notify(ctx context.Context, alerts []Alert) {
+	for name, alerts := range a.byReceiver(alerts) {
+		receiver, ok := a.Receivers[name]
+		if !ok {
+			continue
+		}
+		if err := receiver.Notify(ctx, alerts); err != nil {
+			a.Log.Error(err, "Unable to notify alerts", "receiver", name, "alerts", len(alerts))
+			continue
+		}
+		a.Log.Info("Alerts notified", "receiver", name, "alerts", len(alerts))
+	}
+}
+
+// Resend re-sends the firing alerts to the receivers expiring them, every interval until the context is done
+func (a *Alerter) Resend(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		a.mu.Lock()
+		firing := a.Evaluator.Firing()
+		a.mu.Unlock()
+		for name, alerts := range a.byReceiver(firing) {
+			receiver, ok := a.Receivers[name].(Resender)
+			if !ok {
+				continue
+			}
+			if err := receiver.Resend(ctx, alerts); err != nil {
+				a.Log.Error(err, "Unable to re-send firing alerts", "receiver", name, "alerts", len(alerts))
+			}
+		}
+	}
+}
+
+func (a *Alerter) byReceiver(alerts []Alert) map[string][]Alert {
+	byReceiver := map[string][]Alert{}
+	for _, alert := range alerts {
+		receivers := alert.Receivers
+		if len(receivers) == 0 {
+			for name := range a.Receivers {
+				receivers = append(receivers, name)
+			}
+		}
+		for _, name := range receivers {
+			byReceiver[name] = append(byReceiver[name], alert)
+		}
+	}
+	return byReceiver
+}
+
+// Run consumes the results of all the partitions of the source topics until the context is done. The results written
+// in the lookback before startup are evaluated first without notifying, restoring the alerts firing before a restart
+// so they are resolved later on.
+func (a *Alerter) Run(ctx context.Context, client sarama.Client, topics map[monitoringv1beta1.AlertSource]string, lookback time.Duration) error {
+	type sourceMessage struct {
+		source  monitoringv1beta1.AlertSource
+		message *sarama.ConsumerMessage
+		restore bool
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	var partitionConsumers []sarama.PartitionConsumer
+	defer func() {
+		for _, partitionConsumer := range partitionConsumers {
+			partitionConsumer.AsyncClose()
+		}
+	}()
+
+	messages := make(chan sourceMessage)
+	since := time.Now().Add(-lookback).UnixNano() / int64(time.Millisecond)
+	for source, topic := range topics {
+		partitions, err := consumer.Partitions(topic)
+		if err != nil {
+			return err
+		}
+		for _, partition := range partitions {
+			// Messages before the newest offset at startup are restored
+			newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return err
+			}
+			offset, err := client.GetOffset(topic, partition, since)
+			if err != nil {
+				return err
+			}
+			if offset < 0 {
+				offset = newest
+			}
+			partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+			if err != nil {
+				return err
+			}
+			partitionConsumers = append(partitionConsumers, partitionConsumer)
+			go func(source monitoringv1beta1.AlertSource, partitionConsumer sarama.PartitionConsumer, newest int64) {
+				for message := range partitionConsumer.Messages() {
+					select {
+					case messages <- sourceMessage{source: source, message: message, restore: message.Offset < newest}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(source, partitionConsumer, newest)
+		}
+		a.Log.Info("Consuming results", "source", source, "topic", topic, "partitions", len(partitions))
+	}
+
+	for {
+		select {
+		case m := <-messages:
+			var err error
+			if m.restore {
+				err = a.Restore(m.source, m.message.Value)
+			} else {
+				err = a.Handle(ctx, m.source, m.message.Value)
+			}
+			if err != nil {
+				a.Log.Error(err, "Skipping result", "source", m.source, "partition", m.message.Partition, "offset", m.message.Offset)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
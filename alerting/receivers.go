@@ -0,0 +1,171 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Receiver notifies alerts
+type Receiver interface {
+	Notify(ctx context.Context, alerts []Alert) error
+}
+
+// Resender is a Receiver expiring the firing alerts that are not re-sent
+type Resender interface {
+	Receiver
+	Resend(ctx context.Context, firing []Alert) error
+}
+
+// Labels added to the alerts sent to Alertmanager
+const (
+	AlertNameLabel    = "alertname"
+	SeverityLabel     = "severity"
+	ModelMonitorLabel = "modelmonitor"
+	NamespaceLabel    = "namespace"
+	SourceLabel       = "source"
+	DetectorLabel     = "detector"
+	FeatureLabel      = "feature"
+	RevisionLabel     = "revision"
+)
+
+// AlertmanagerReceiver posts the alerts to the v2 API of a Prometheus Alertmanager
+type AlertmanagerReceiver struct {
+	Client    *http.Client
+	URL       string
+	Name      string
+	Namespace string
+}
+
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+}
+
+// Notify posts the alerts, with the ModelMonitor and the rule labels. Resolved alerts are sent with their end time.
+func (r *AlertmanagerReceiver) Notify(ctx context.Context, alerts []Alert) error {
+	var payload []alertmanagerAlert
+	for i := range alerts {
+		alert := &alerts[i]
+		amAlert := alertmanagerAlert{
+			Labels: map[string]string{
+				AlertNameLabel:    alert.Rule,
+				SeverityLabel:     string(alert.Severity),
+				ModelMonitorLabel: r.Name,
+				NamespaceLabel:    r.Namespace,
+				SourceLabel:       string(alert.Source),
+				DetectorLabel:     alert.Detector,
+				FeatureLabel:      alert.Feature,
+			},
+			Annotations: map[string]string{
+				"summary":   summary(alert),
+				"value":     strconv.FormatFloat(alert.Value, 'g', -1, 64),
+				"threshold": strconv.FormatFloat(alert.Threshold, 'g', -1, 64),
+			},
+			StartsAt: alert.StartsAt,
+		}
+		if alert.Revision != "" {
+			amAlert.Labels[RevisionLabel] = alert.Revision
+		}
+		if alert.Resolved() {
+			endsAt := alert.EndsAt
+			amAlert.EndsAt = &endsAt
+		}
+		payload = append(payload, amAlert)
+	}
+	return postJSON(ctx, r.Client, strings.TrimSuffix(r.URL, "/")+"/api/v2/alerts", payload)
+}
+
+// Resend posts the firing alerts again, so Alertmanager doesn't resolve them after its resolve_timeout
+func (r *AlertmanagerReceiver) Resend(ctx context.Context, firing []Alert) error {
+	return r.Notify(ctx, firing)
+}
+
+// SlackReceiver posts the alerts as a message to a Slack-compatible incoming webhook
+type SlackReceiver struct {
+	Client    *http.Client
+	URL       string
+	Channel   string
+	Name      string
+	Namespace string
+}
+
+type slackMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Notify posts a message with a line per alert
+func (r *SlackReceiver) Notify(ctx context.Context, alerts []Alert) error {
+	lines := []string{fmt.Sprintf("ModelMonitor %s/%s", r.Namespace, r.Name)}
+	for i := range alerts {
+		status := "FIRING"
+		if alerts[i].Resolved() {
+			status = "RESOLVED"
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s (%s): %s", status, alerts[i].Rule, alerts[i].Severity, summary(&alerts[i])))
+	}
+	return postJSON(ctx, r.Client, r.URL, slackMessage{Channel: r.Channel, Text: strings.Join(lines, "\n")})
+}
+
+// EventsReceiver records the alerts as Events of the ModelMonitor, warnings when firing
+type EventsReceiver struct {
+	Recorder record.EventRecorder
+	Object   runtime.Object
+}
+
+// Notify records an Event per alert
+func (r *EventsReceiver) Notify(ctx context.Context, alerts []Alert) error {
+	for i := range alerts {
+		if alerts[i].Resolved() {
+			r.Recorder.Eventf(r.Object, corev1.EventTypeNormal, "AlertResolved", "%s: %s", alerts[i].Rule, summary(&alerts[i]))
+			continue
+		}
+		r.Recorder.Eventf(r.Object, corev1.EventTypeWarning, "AlertFiring", "%s: %s", alerts[i].Rule, summary(&alerts[i]))
+	}
+	return nil
+}
+
+func summary(alert *Alert) string {
+	feature := alert.Feature
+	if alert.Revision != "" {
+		feature += " (" + alert.Revision + " revision)"
+	}
+	if alert.Resolved() {
+		return fmt.Sprintf("%s %s of feature %s is %g, back under %g", alert.Source, alert.Detector, feature, alert.Value, alert.Threshold)
+	}
+	return fmt.Sprintf("%s %s of feature %s is %g, over %g for %d windows", alert.Source, alert.Detector, feature, alert.Value, alert.Threshold, alert.Windows)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Unexpected status %s from %s", resp.Status, req.URL.Host)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func newTestServer(g *gomega.WithT, requests chan<- []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		requests <- append([]byte(r.URL.Path+" "), body...)
+	}))
+}
+
+func TestAlerterNotifiesAlertmanager(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	requests := make(chan []byte, 1)
+	server := newTestServer(g, requests)
+	defer server.Close()
+
+	evaluator, err := NewEvaluator([]monitoringv1beta1.AlertRuleSpec{
+		{Name: "drift", Source: monitoringv1beta1.DriftAlertSource, Threshold: "0.3", ConsecutiveWindows: 1, Severity: monitoringv1beta1.WarningAlertSeverity},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	alerter := &Alerter{
+		Evaluator: evaluator,
+		Receivers: map[string]Receiver{
+			"alertmanager": &AlertmanagerReceiver{Client: server.Client(), URL: server.URL, Name: "iris", Namespace: "default"},
+		},
+		Log: ctrl.Log,
+	}
+
+	payload := `[{"window": {"start": "2020-06-01T10:00:00Z", "end": "2020-06-01T10:01:00Z"}, "feature": "sepal_length", "detector": "wasserstein", "value": 0.42}]`
+	g.Expect(alerter.Handle(context.TODO(), monitoringv1beta1.DriftAlertSource, []byte(payload))).To(gomega.Succeed())
+
+	request := string(<-requests)
+	g.Expect(request).To(gomega.HavePrefix("/api/v2/alerts "))
+	var alerts []alertmanagerAlert
+	g.Expect(json.Unmarshal([]byte(request[len("/api/v2/alerts "):]), &alerts)).To(gomega.Succeed())
+	g.Expect(alerts).To(gomega.HaveLen(1))
+	g.Expect(alerts[0].Labels).To(gomega.HaveKeyWithValue(AlertNameLabel, "drift"))
+	g.Expect(alerts[0].Labels).To(gomega.HaveKeyWithValue(ModelMonitorLabel, "iris"))
+	g.Expect(alerts[0].Labels).To(gomega.HaveKeyWithValue(FeatureLabel, "sepal_length"))
+	g.Expect(alerts[0].EndsAt).To(gomega.BeNil())
+
+	// Invalid results are reported
+	g.Expect(alerter.Handle(context.TODO(), monitoringv1beta1.DriftAlertSource, []byte("{"))).NotTo(gomega.Succeed())
+}
+
+func TestAlerterResendsFiringAlerts(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	requests := make(chan []byte, 10)
+	server := newTestServer(g, requests)
+	defer server.Close()
+
+	evaluator, err := NewEvaluator([]monitoringv1beta1.AlertRuleSpec{
+		{Name: "drift", Source: monitoringv1beta1.DriftAlertSource, Threshold: "0.3"},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	recorder := record.NewFakeRecorder(2)
+	alerter := &Alerter{
+		Evaluator: evaluator,
+		Receivers: map[string]Receiver{
+			"alertmanager": &AlertmanagerReceiver{Client: server.Client(), URL: server.URL, Name: "iris", Namespace: "default"},
+			"events":       &EventsReceiver{Recorder: recorder, Object: &corev1.ObjectReference{Kind: "ModelMonitor", Name: "iris"}},
+		},
+		Log: ctrl.Log,
+	}
+
+	// Restored alerts are not notified
+	payload := `{"window": {"start": "2020-06-01T10:00:00Z", "end": "2020-06-01T10:01:00Z"}, "feature": "sepal_length", "detector": "wasserstein", "value": 0.42}`
+	g.Expect(alerter.Restore(monitoringv1beta1.DriftAlertSource, []byte(payload))).To(gomega.Succeed())
+	g.Expect(requests).To(gomega.BeEmpty())
+	g.Expect(recorder.Events).To(gomega.BeEmpty())
+
+	// Firing alerts are re-sent to Alertmanager only
+	ctx, cancel := context.WithCancel(context.TODO())
+	go alerter.Resend(ctx, 10*time.Millisecond)
+	request := string(<-requests)
+	cancel()
+	var alerts []alertmanagerAlert
+	g.Expect(json.Unmarshal([]byte(request[len("/api/v2/alerts "):]), &alerts)).To(gomega.Succeed())
+	g.Expect(alerts).To(gomega.HaveLen(1))
+	g.Expect(alerts[0].EndsAt).To(gomega.BeNil())
+	g.Expect(recorder.Events).To(gomega.BeEmpty())
+
+	// Restored alerts are resolved
+	payload = `{"window": {"start": "2020-06-01T10:01:00Z", "end": "2020-06-01T10:02:00Z"}, "feature": "sepal_length", "detector": "wasserstein", "value": 0.1}`
+	g.Expect(alerter.Handle(context.TODO(), monitoringv1beta1.DriftAlertSource, []byte(payload))).To(gomega.Succeed())
+	g.Expect(<-recorder.Events).To(gomega.HavePrefix(corev1.EventTypeNormal + " AlertResolved"))
+}
+
+func TestSlackReceiverNotifies(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	requests := make(chan []byte, 1)
+	server := newTestServer(g, requests)
+	defer server.Close()
+
+	receiver := &SlackReceiver{Client: server.Client(), URL: server.URL + "/hooks/abc", Channel: "#models", Name: "iris", Namespace: "default"}
+	alert := Alert{Rule: "drift", Severity: monitoringv1beta1.CriticalAlertSeverity, Source: monitoringv1beta1.DriftAlertSource, Feature: "sepal_length", Value: 0.5, Threshold: 0.3, Windows: 1}
+	g.Expect(receiver.Notify(context.TODO(), []Alert{alert})).To(gomega.Succeed())
+
+	request := string(<-requests)
+	g.Expect(request).To(gomega.HavePrefix("/hooks/abc "))
+	message := slackMessage{}
+	g.Expect(json.Unmarshal([]byte(request[len("/hooks/abc "):]), &message)).To(gomega.Succeed())
+	g.Expect(message.Channel).To(gomega.Equal("#models"))
+	g.Expect(message.Text).To(gomega.ContainSubstring("[FIRING] drift (critical)"))
+}
+
+func TestEventsReceiverRecordsEvents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(2)
+	receiver := &EventsReceiver{Recorder: recorder, Object: &corev1.ObjectReference{Kind: "ModelMonitor", Name: "iris", Namespace: "default"}}
+
+	firing := Alert{Rule: "drift", Source: monitoringv1beta1.DriftAlertSource, Feature: "sepal_length", Value: 0.5, Threshold: 0.3, Windows: 1}
+	resolved := firing
+	resolved.EndsAt = resolved.StartsAt.Add(1)
+	g.Expect(receiver.Notify(context.TODO(), []Alert{firing, resolved})).To(gomega.Succeed())
+
+	g.Expect(<-recorder.Events).To(gomega.HavePrefix(corev1.EventTypeWarning + " AlertFiring"))
+	g.Expect(<-recorder.Events).To(gomega.HavePrefix(corev1.EventTypeNormal + " AlertResolved"))
+}
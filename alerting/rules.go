@@ -0,0 +1,193 @@
+package alerting
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+)
+
+// Result defines a drift or outlier result of the Monitoring job, for a feature and a detector in a window. Drift
+// results carry the distance to the baseline and outlier results the outliers found, as value.
+type Result struct {
+	Window   Window `json:"window"`
+	Feature  string `json:"feature"`
+	Detector string `json:"detector"`
+	// Revision is the endpoint (default or canary) of the revision analysed, empty unless analysed per revision
+	Revision string  `json:"revision,omitempty"`
+	Value    float64 `json:"value"`
+}
+
+// Window defines the time window a result was computed on
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Alert defines a rule firing or resolving for a feature
+type Alert struct {
+	Rule      string
+	Severity  monitoringv1beta1.AlertSeverity
+	Source    monitoringv1beta1.AlertSource
+	Detector  string
+	Feature   string
+	Revision  string
+	Value     float64
+	Threshold float64
+	// Windows alerting in a row
+	Windows int32
+	// Resolved alerts have an end time
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Names of the receivers to notify, all if empty
+	Receivers []string
+}
+
+// Resolved returns whether the alert stopped firing
+func (a *Alert) Resolved() bool {
+	return !a.EndsAt.IsZero()
+}
+
+// Evaluator evaluates the alerting rules on the results, keeping the alerting windows of each rule, feature, detector
+// and revision
+type Evaluator struct {
+	rules  []rule
+	states map[stateKey]*state
+}
+
+type rule struct {
+	monitoringv1beta1.AlertRuleSpec
+	threshold float64
+}
+
+type stateKey struct {
+	rule, feature, detector, revision string
+}
+
+// state defines the alerting windows in a row of a rule for a feature, detector and revision, with the alert while firing
+type state struct {
+	window   time.Time
+	windows  int32
+	firing   *Alert
+	startsAt time.Time
+}
+
+// NewEvaluator creates an evaluator for the given rules, with the defaults applied
+func NewEvaluator(rules []monitoringv1beta1.AlertRuleSpec) (*Evaluator, error) {
+	evaluator := &Evaluator{states: map[stateKey]*state{}}
+	for _, spec := range rules {
+		threshold, err := strconv.ParseFloat(spec.Threshold, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid threshold %q of rule %s: %v", spec.Threshold, spec.Name, err)
+		}
+		evaluator.rules = append(evaluator.rules, rule{AlertRuleSpec: spec, threshold: threshold})
+	}
+	return evaluator, nil
+}
+
+// Evaluate evaluates the rules of a source on a result, returning the alerts firing or resolving. Results of a
+// window already evaluated are ignored.
+func (e *Evaluator) Evaluate(source monitoringv1beta1.AlertSource, result *Result) []Alert {
+	var alerts []Alert
+	for _, rule := range e.rules {
+		if !rule.matches(source, result) {
+			continue
+		}
+
+		key := stateKey{rule: rule.Name, feature: result.Feature, detector: result.Detector, revision: result.Revision}
+		st, ok := e.states[key]
+		if !ok {
+			st = &state{}
+			e.states[key] = st
+		}
+		if !result.Window.End.After(st.window) {
+			continue
+		}
+		st.window = result.Window.End
+
+		alert := Alert{
+			Rule:      rule.Name,
+			Severity:  rule.Severity,
+			Source:    source,
+			Detector:  result.Detector,
+			Feature:   result.Feature,
+			Revision:  result.Revision,
+			Value:     result.Value,
+			Threshold: rule.threshold,
+			Receivers: rule.Receivers,
+		}
+		if result.Value <= rule.threshold {
+			if st.firing != nil {
+				alert.Windows = st.windows
+				alert.StartsAt = st.startsAt
+				alert.EndsAt = result.Window.End
+				alerts = append(alerts, alert)
+			}
+			*st = state{window: st.window}
+			continue
+		}
+
+		st.windows++
+		if st.windows == 1 {
+			st.startsAt = result.Window.Start
+		}
+		alert.Windows = st.windows
+		alert.StartsAt = st.startsAt
+		if st.firing != nil {
+			st.firing = &alert
+			continue
+		}
+		if st.windows >= rule.consecutiveWindows() {
+			st.firing = &alert
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// Firing returns the alerts firing, with the value of their last window, sorted by rule, feature, detector and revision
+func (e *Evaluator) Firing() []Alert {
+	var alerts []Alert
+	for _, st := range e.states {
+		if st.firing != nil {
+			alerts = append(alerts, *st.firing)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Rule != alerts[j].Rule {
+			return alerts[i].Rule < alerts[j].Rule
+		}
+		if alerts[i].Feature != alerts[j].Feature {
+			return alerts[i].Feature < alerts[j].Feature
+		}
+		if alerts[i].Detector != alerts[j].Detector {
+			return alerts[i].Detector < alerts[j].Detector
+		}
+		return alerts[i].Revision < alerts[j].Revision
+	})
+	return alerts
+}
+
+func (r *rule) matches(source monitoringv1beta1.AlertSource, result *Result) bool {
+	if r.Source != source || (r.Detector != "" && r.Detector != result.Detector) {
+		return false
+	}
+	if len(r.Features) == 0 {
+		return true
+	}
+	for _, feature := range r.Features {
+		if feature == result.Feature {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) consecutiveWindows() int32 {
+	if r.ConsecutiveWindows < 1 {
+		return 1
+	}
+	return r.ConsecutiveWindows
+}
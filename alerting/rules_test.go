@@ -0,0 +1,103 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/onsi/gomega"
+)
+
+func newTestResult(minute int, feature string, value float64) *Result {
+	start := time.Date(2020, 6, 1, 10, minute, 0, 0, time.UTC)
+	return &Result{
+		Window:   Window{Start: start, End: start.Add(time.Minute)},
+		Feature:  feature,
+		Detector: "jensenShannon",
+		Value:    value,
+	}
+}
+
+func TestEvaluatorFiresAfterConsecutiveWindows(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	evaluator, err := NewEvaluator([]monitoringv1beta1.AlertRuleSpec{
+		{Name: "drift", Source: monitoringv1beta1.DriftAlertSource, Detector: "jensenShannon", Threshold: "0.3", ConsecutiveWindows: 2, Severity: monitoringv1beta1.CriticalAlertSeverity},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(0, "sepal_length", 0.5))).To(gomega.BeEmpty())
+	// Results of other sources or windows already evaluated are ignored
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.OutliersAlertSource, newTestResult(1, "sepal_length", 0.5))).To(gomega.BeEmpty())
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(0, "sepal_length", 0.5))).To(gomega.BeEmpty())
+
+	alerts := evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(1, "sepal_length", 0.4))
+	g.Expect(alerts).To(gomega.HaveLen(1))
+	g.Expect(alerts[0].Resolved()).To(gomega.BeFalse())
+	g.Expect(alerts[0].Windows).To(gomega.Equal(int32(2)))
+	g.Expect(alerts[0].StartsAt).To(gomega.Equal(newTestResult(0, "", 0).Window.Start))
+	g.Expect(alerts[0].Severity).To(gomega.Equal(monitoringv1beta1.CriticalAlertSeverity))
+
+	// Fired once, kept firing with the last value
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(2, "sepal_length", 0.45))).To(gomega.BeEmpty())
+	firing := evaluator.Firing()
+	g.Expect(firing).To(gomega.HaveLen(1))
+	g.Expect(firing[0].Value).To(gomega.Equal(0.45))
+	g.Expect(firing[0].Windows).To(gomega.Equal(int32(3)))
+	g.Expect(firing[0].StartsAt).To(gomega.Equal(alerts[0].StartsAt))
+
+	alerts = evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(3, "sepal_length", 0.1))
+	g.Expect(alerts).To(gomega.HaveLen(1))
+	g.Expect(alerts[0].Resolved()).To(gomega.BeTrue())
+	g.Expect(evaluator.Firing()).To(gomega.BeEmpty())
+}
+
+func TestEvaluatorBreaksConsecutiveWindows(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	evaluator, err := NewEvaluator([]monitoringv1beta1.AlertRuleSpec{
+		{Name: "drift", Source: monitoringv1beta1.DriftAlertSource, Features: []string{"sepal_length"}, Threshold: "0.3", ConsecutiveWindows: 2},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(0, "sepal_length", 0.5))).To(gomega.BeEmpty())
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(1, "sepal_length", 0.2))).To(gomega.BeEmpty())
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(2, "sepal_length", 0.5))).To(gomega.BeEmpty())
+	// Other features are not matched
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(0, "petal_length", 0.9))).To(gomega.BeEmpty())
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newTestResult(1, "petal_length", 0.9))).To(gomega.BeEmpty())
+}
+
+func TestEvaluatorKeepsRevisionsApart(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	evaluator, err := NewEvaluator([]monitoringv1beta1.AlertRuleSpec{
+		{Name: "drift", Source: monitoringv1beta1.DriftAlertSource, Threshold: "0.3", ConsecutiveWindows: 2},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	newRevisionResult := func(minute int, revision string, value float64) *Result {
+		result := newTestResult(minute, "sepal_length", value)
+		result.Revision = revision
+		return result
+	}
+
+	// Results of both revisions in the same window are evaluated
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newRevisionResult(0, "default", 0.1))).To(gomega.BeEmpty())
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newRevisionResult(0, "canary", 0.5))).To(gomega.BeEmpty())
+
+	// Consecutive windows are counted per revision
+	g.Expect(evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newRevisionResult(1, "default", 0.5))).To(gomega.BeEmpty())
+	alerts := evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newRevisionResult(1, "canary", 0.5))
+	g.Expect(alerts).To(gomega.HaveLen(1))
+	g.Expect(alerts[0].Revision).To(gomega.Equal("canary"))
+	g.Expect(alerts[0].Windows).To(gomega.Equal(int32(2)))
+
+	alerts = evaluator.Evaluate(monitoringv1beta1.DriftAlertSource, newRevisionResult(2, "default", 0.5))
+	g.Expect(alerts).To(gomega.HaveLen(1))
+	g.Expect(alerts[0].Revision).To(gomega.Equal("default"))
+	g.Expect(evaluator.Firing()).To(gomega.HaveLen(2))
+}
+
+func TestNewEvaluatorInvalidThreshold(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := NewEvaluator([]monitoringv1beta1.AlertRuleSpec{{Name: "drift", Threshold: "high"}})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -0,0 +1,82 @@
+package v1beta1
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AlertSourceKafka returns the Kafka settings of the analysis sink an alert source is read from, nil if it isn't a Kafka sink
+func (s *AnalysisSpec) AlertSourceKafka(source AlertSource) *KafkaSpec {
+	var sink *SinkSpec
+	switch source {
+	case DriftAlertSource:
+		sink = s.Drift
+	case OutliersAlertSource:
+		sink = s.Outliers
+	}
+	if sink == nil {
+		return nil
+	}
+	return sink.Kafka
+}
+
+// Sources returns the analysis results the rules are evaluated on, without duplicates
+func (s *AlertingSpec) Sources() []AlertSource {
+	var sources []AlertSource
+	seen := map[AlertSource]bool{}
+	for _, rule := range s.Rules {
+		if !seen[rule.Source] {
+			seen[rule.Source] = true
+			sources = append(sources, rule.Source)
+		}
+	}
+	return sources
+}
+
+// SecretNames returns the names of the Secrets referenced by the receivers
+func (s *AlertingSpec) SecretNames() []string {
+	var selectors []*corev1.SecretKeySelector
+	for i := range s.Receivers {
+		if slack := s.Receivers[i].Slack; slack != nil {
+			selectors = append(selectors, &slack.URL)
+		}
+	}
+	return secretNames(selectors)
+}
+
+// Types returns the names of the receiver kinds set. Valid receivers have exactly one.
+func (s *AlertReceiverSpec) Types() []string {
+	var types []string
+	if s.Alertmanager != nil {
+		types = append(types, AlertmanagerReceiverType)
+	}
+	if s.Slack != nil {
+		types = append(types, SlackReceiverType)
+	}
+	if s.Events != nil {
+		types = append(types, EventsReceiverType)
+	}
+	return types
+}
+
+// detectors returns the names of the drift detectors enabled
+func (s *DriftSpec) detectors() map[string]bool {
+	return map[string]bool{
+		"wasserstein":     s.Wasserstein != nil,
+		"kullbackLeibler": s.KullbackLeibler != nil,
+		"jensenShannon":   s.JensenShannon != nil,
+	}
+}
+
+// Metrics returns the names of the drift detectors enabled, sorted
+func (s *DriftSpec) Metrics() []string {
+	var metrics []string
+	for detector, enabled := range s.detectors() {
+		if enabled {
+			metrics = append(metrics, detector)
+		}
+	}
+	sort.Strings(metrics)
+	return metrics
+}
@@ -0,0 +1,169 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Object store URI schemes supported by the Monitoring job
+var baselineURISchemes = []string{"s3", "s3a", "gs", "hdfs", "http", "https"}
+
+// NamedBaseline defines a baseline along with its name, matching the BaselineSpec fields
+// +kubebuilder:object:generate=false
+type NamedBaseline struct {
+	Name   string
+	Inline string
+	From   *BaselineSource
+	// Instance features the baseline must include
+	Features []string
+}
+
+// Baselines returns the descriptive and distributions baselines, with the features they must include. Descriptive
+// baselines include the features of the stats, and distributions baselines the features of the drift detectors.
+func (s *MonitoringSpec) Baselines(instance *Schema) []NamedBaseline {
+	if s.Baseline == nil {
+		return nil
+	}
+
+	descriptiveFeatures := s.Stats.Features
+	distributionsFeatures := descriptiveFeatures
+	if s.Drift != nil && len(s.Drift.Features) > 0 {
+		distributionsFeatures = s.Drift.Features
+	}
+	if len(descriptiveFeatures) == 0 {
+		descriptiveFeatures = instance.fieldNames()
+	}
+	if len(distributionsFeatures) == 0 {
+		distributionsFeatures = instance.fieldNames()
+	}
+
+	return []NamedBaseline{
+		{Name: "descriptive", Inline: s.Baseline.Descriptive, From: s.Baseline.DescriptiveFrom, Features: descriptiveFeatures},
+		{Name: "distributions", Inline: s.Baseline.Distributions, From: s.Baseline.DistributionsFrom, Features: distributionsFeatures},
+	}
+}
+
+// SecretNames returns the names of the Secrets referenced by the baselines
+func (s *MonitoringSpec) SecretNames() []string {
+	var selectors []*corev1.SecretKeySelector
+	for _, source := range s.baselineSources() {
+		selectors = append(selectors, source.SecretKeyRef)
+	}
+	return secretNames(selectors)
+}
+
+// ConfigMapNames returns the names of the ConfigMaps referenced by the baselines
+func (s *MonitoringSpec) ConfigMapNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, source := range s.baselineSources() {
+		if source.ConfigMapKeyRef == nil || seen[source.ConfigMapKeyRef.Name] {
+			continue
+		}
+		seen[source.ConfigMapKeyRef.Name] = true
+		names = append(names, source.ConfigMapKeyRef.Name)
+	}
+	return names
+}
+
+// ComputesBaselines returns whether the baselines are computed from a training dataset or from inference traffic
+func (s *MonitoringSpec) ComputesBaselines() bool {
+	return s.Baseline != nil && (s.Baseline.Dataset != nil || s.Baseline.Traffic != nil)
+}
+
+func configMapBaselineSource(name string, key string) *BaselineSource {
+	return &BaselineSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}}
+}
+
+func (s *MonitoringSpec) baselineSources() []*BaselineSource {
+	var sources []*BaselineSource
+	if s.Baseline == nil {
+		return sources
+	}
+	for _, source := range []*BaselineSource{s.Baseline.DescriptiveFrom, s.Baseline.DistributionsFrom} {
+		if source != nil {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// ValidateBaseline checks that a baseline is a JSON object with an entry per feature, and only for instance features
+func ValidateBaseline(data string, instance *Schema, features []string) error {
+	var baseline map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &baseline); err != nil {
+		return fmt.Errorf("baseline must be a JSON object by feature: %v", err)
+	}
+
+	var unknown, missing []string
+	for feature := range baseline {
+		if instance.Field(feature) == nil {
+			unknown = append(unknown, feature)
+		}
+	}
+	for _, feature := range features {
+		if _, ok := baseline[feature]; !ok {
+			missing = append(missing, feature)
+		}
+	}
+	sort.Strings(unknown)
+
+	var problems []string
+	if len(unknown) > 0 {
+		problems = append(problems, "features not in the instance schema: "+strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "missing features: "+strings.Join(missing, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ParseRebaselineTime returns the time of a re-baseline request from the value of the re-baseline annotation, a Unix
+// timestamp in seconds or an RFC 3339 time. The request time is derived from the value, so that it is the same on
+// every reconciliation.
+func ParseRebaselineTime(value string) (metav1.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return metav1.Unix(seconds, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return metav1.Time{}, fmt.Errorf(InvalidRebaselineError)
+	}
+	return metav1.NewTime(t), nil
+}
+
+// validateBaselineURI checks that a baseline URI has a scheme supported by the Monitoring job
+func validateBaselineURI(value string) error {
+	uri, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	for _, scheme := range baselineURISchemes {
+		if uri.Scheme == scheme && uri.Host != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be an object store URI (%s)", strings.Join(baselineURISchemes, ", "))
+}
+
+func (s *Schema) fieldNames() []string {
+	var names []string
+	for _, field := range s.Fields {
+		names = append(names, field.Name)
+	}
+	return names
+}
@@ -1,15 +1,13 @@
 package v1beta1
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/javierdlrm/model-monitoring-operator/constants"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ModelMonitorConfig defines the ModelMonitor configuration
@@ -17,6 +15,10 @@ import (
 type ModelMonitorConfig struct {
 	Job             *JobConfig             `json:"job"`
 	InferenceLogger *InferenceLoggerConfig `json:"inferenceLogger"`
+	// Alerter is optional, alerting rules are not evaluated without it
+	Alerter *AlerterConfig `json:"alerter,omitempty"`
+	// Revision is the resource version of the ConfigMap the config was loaded from
+	Revision string `json:"-"`
 }
 
 // InferenceLoggerConfig defines the configuration for the InferenceLogger service
@@ -25,29 +27,38 @@ type InferenceLoggerConfig struct {
 	ContainerImage string `json:"containerImage"`
 }
 
+// AlerterConfig defines the configuration for the Alerter
+// +k8s:openapi-gen=false
+type AlerterConfig struct {
+	ContainerImage string `json:"containerImage"`
+}
+
 // JobConfig defines the configuration for the Monitoring job
 // +k8s:openapi-gen=false
 type JobConfig struct {
-	ContainerImage      string `json:"containerImage"`
-	MainClass           string `json:"mainClass"`
-	MainApplicationFile string `json:"mainApplicationFile"`
+	// Backend is the kind of workload the job runs on, defaults to spark
+	Backend        JobBackendType `json:"backend,omitempty"`
+	ContainerImage string         `json:"containerImage"`
+	// Main class and application file are only used by the spark backend
+	MainClass           string `json:"mainClass,omitempty"`
+	MainApplicationFile string `json:"mainApplicationFile,omitempty"`
 }
 
-// GetModelMonitorConfig returns the ModelMonitor config
-func GetModelMonitorConfig(client client.Client) (*ModelMonitorConfig, error) {
-	configMap := &corev1.ConfigMap{}
-
-	err := client.Get(context.TODO(), types.NamespacedName{Name: constants.ModelMonitorConfigMapName, Namespace: constants.ModelMonitoringNamespace}, configMap)
-	if err != nil {
-		return nil, err
-	}
+// JobBackendType defines the kind of workload the Monitoring job runs on
+type JobBackendType string
 
-	modelMonitorConfigMap, err := NewModelMonitorConfig(configMap)
-	if err != nil {
-		return nil, err
-	}
+// JobBackendType values
+const (
+	// SparkJobBackend runs the job as Spark Applications, requires the Spark operator
+	SparkJobBackend JobBackendType = "spark"
+	// DeploymentJobBackend runs streaming jobs as a single replica Deployment of a lightweight job image
+	DeploymentJobBackend JobBackendType = "deployment"
+)
 
-	return modelMonitorConfigMap, nil
+// RunsBaselineJob returns whether the Baseline job runs on the configured backend. The Baseline job is a Spark
+// Application, so it does not run on the deployment backend.
+func (c *JobConfig) RunsBaselineJob() bool {
+	return c.Backend != DeploymentJobBackend
 }
 
 // NewModelMonitorConfig creates a ModelMonitorConfig from a given configmap
@@ -63,14 +74,62 @@ func NewModelMonitorConfig(configMap *corev1.ConfigMap) (*ModelMonitorConfig, er
 		return nil, err
 	}
 
-	return &ModelMonitorConfig{
+	alerterConfig, err := getAlerterConfig(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	modelMonitorConfig := &ModelMonitorConfig{
 		Job:             jobConfig,
 		InferenceLogger: inferenceLoggerConfig,
-	}, nil
+		Alerter:         alerterConfig,
+		Revision:        configMap.ResourceVersion,
+	}
+	if err = modelMonitorConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	return modelMonitorConfig, nil
+}
+
+// Validate checks that all the required fields of the configuration are set
+func (c *ModelMonitorConfig) Validate() error {
+	if c.Job == nil || c.InferenceLogger == nil {
+		return fmt.Errorf("Missing %s or %s in ModelMonitor config", constants.Job, constants.InferenceLogger)
+	}
+	var missing []string
+
+	if c.InferenceLogger.ContainerImage == "" {
+		missing = append(missing, constants.InferenceLogger.String()+".containerImage")
+	}
+	if c.Job.ContainerImage == "" {
+		missing = append(missing, constants.Job.String()+".containerImage")
+	}
+	switch c.Job.Backend {
+	case SparkJobBackend:
+		if c.Job.MainClass == "" {
+			missing = append(missing, constants.Job.String()+".mainClass")
+		}
+		if c.Job.MainApplicationFile == "" {
+			missing = append(missing, constants.Job.String()+".mainApplicationFile")
+		}
+	case DeploymentJobBackend:
+	default:
+		return fmt.Errorf("Unknown Monitoring job backend %q in ModelMonitor config, must be %s or %s", c.Job.Backend,
+			SparkJobBackend, DeploymentJobBackend)
+	}
+	if c.Alerter != nil && c.Alerter.ContainerImage == "" {
+		missing = append(missing, constants.Alerter.String()+".containerImage")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Missing required fields in ModelMonitor config: %v", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func getJobConfig(configMap *corev1.ConfigMap) (*JobConfig, error) {
-	jobConfig := &JobConfig{}
+	var jobConfig JobConfig
 	key := constants.Job.String()
 
 	if data, ok := configMap.Data[key]; ok {
@@ -79,11 +138,14 @@ func getJobConfig(configMap *corev1.ConfigMap) (*JobConfig, error) {
 			return nil, fmt.Errorf("Unable to unmarshall %v json string due to %v ", key, err)
 		}
 	}
-	return jobConfig, nil
+	if jobConfig.Backend == "" {
+		jobConfig.Backend = SparkJobBackend
+	}
+	return &jobConfig, nil
 }
 
 func getInferenceLoggerConfig(configMap *corev1.ConfigMap) (*InferenceLoggerConfig, error) {
-	inferenceLoggerConfig := &InferenceLoggerConfig{}
+	var inferenceLoggerConfig InferenceLoggerConfig
 	key := constants.InferenceLogger.String()
 
 	if data, ok := configMap.Data[key]; ok {
@@ -93,5 +155,20 @@ func getInferenceLoggerConfig(configMap *corev1.ConfigMap) (*InferenceLoggerConf
 		}
 	}
 
-	return inferenceLoggerConfig, nil
+	return &inferenceLoggerConfig, nil
+}
+
+func getAlerterConfig(configMap *corev1.ConfigMap) (*AlerterConfig, error) {
+	key := constants.Alerter.String()
+	data, ok := configMap.Data[key]
+	if !ok {
+		return nil, nil
+	}
+
+	var alerterConfig AlerterConfig
+	err := json.Unmarshal([]byte(data), &alerterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to unmarshall %v json string due to %v ", key, err)
+	}
+	return &alerterConfig, nil
 }
@@ -0,0 +1,74 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{Data: map[string]string{
+		"inferenceLogger": `{"containerImage": "inference-logger"}`,
+		"job":             `{"containerImage": "job", "mainClass": "Monitor", "mainApplicationFile": "local:///job.jar"}`,
+	}}
+}
+
+func TestNewModelMonitorConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configMap := newTestConfigMap()
+	configMap.ResourceVersion = "42"
+
+	config, err := NewModelMonitorConfig(configMap)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config.Job.Backend).To(gomega.Equal(SparkJobBackend))
+	g.Expect(config.InferenceLogger.ContainerImage).To(gomega.Equal("inference-logger"))
+	g.Expect(config.Alerter).To(gomega.BeNil())
+	g.Expect(config.Revision).To(gomega.Equal("42"))
+}
+
+func TestNewModelMonitorConfigErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		key   string
+		value *string
+		err   string
+	}{
+		"null job":                   {key: "job", value: strPtr("null"), err: "job.containerImage"},
+		"null inference logger":      {key: "inferenceLogger", value: strPtr("null"), err: "inferenceLogger.containerImage"},
+		"null alerter":               {key: "alerter", value: strPtr("null"), err: "alerter.containerImage"},
+		"missing job":                {key: "job", err: "job.containerImage"},
+		"missing inference logger":   {key: "inferenceLogger", err: "inferenceLogger.containerImage"},
+		"malformed job":              {key: "job", value: strPtr(`{"containerImage": `), err: "Unable to unmarshall job"},
+		"malformed inference logger": {key: "inferenceLogger", value: strPtr(`[]`), err: "Unable to unmarshall inferenceLogger"},
+		"malformed alerter":          {key: "alerter", value: strPtr(`"alerter"`), err: "Unable to unmarshall alerter"},
+		"unknown backend":            {key: "job", value: strPtr(`{"backend": "flink", "containerImage": "job"}`), err: "Unknown Monitoring job backend"},
+		"spark without main class":   {key: "job", value: strPtr(`{"containerImage": "job"}`), err: "job.mainClass"},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			configMap := newTestConfigMap()
+			if scenario.value == nil {
+				delete(configMap.Data, scenario.key)
+			} else {
+				configMap.Data[scenario.key] = *scenario.value
+			}
+
+			_, err := NewModelMonitorConfig(configMap)
+			g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(scenario.err)))
+		})
+	}
+}
+
+func TestModelMonitorConfigValidateNilComponents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect((&ModelMonitorConfig{}).Validate()).To(gomega.HaveOccurred())
+	g.Expect((&ModelMonitorConfig{Job: &JobConfig{}}).Validate()).To(gomega.HaveOccurred())
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Duration is a length of time written as a duration string (e.g 10s, 5m). Integers are read as milliseconds, the
+// format of the window and schedule fields before they took duration strings.
+// +kubebuilder:validation:Type=""
+// +kubebuilder:validation:XIntOrString
+type Duration struct {
+	time.Duration `json:"-"`
+}
+
+// MarshalJSON encodes the Duration as a duration string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+// UnmarshalJSON decodes a Duration from a duration string or an integer of milliseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		duration, err := time.ParseDuration(str)
+		if err != nil {
+			return err
+		}
+		d.Duration = duration
+		return nil
+	}
+
+	var milliseconds int64
+	if err := json.Unmarshal(data, &milliseconds); err != nil {
+		return fmt.Errorf("duration must be a duration string or an integer of milliseconds: %v", err)
+	}
+	d.Duration = time.Duration(milliseconds) * time.Millisecond
+	return nil
+}
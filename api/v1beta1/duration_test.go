@@ -0,0 +1,50 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestTriggerUnmarshalDurations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var trigger TriggerSpec
+	err := json.Unmarshal([]byte(`{"window": {"duration": "10s", "slide": "5s", "watermarkDelay": "1m"}}`), &trigger)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(trigger.Window.Duration.Duration).To(gomega.Equal(10 * time.Second))
+	g.Expect(trigger.Window.Slide.Duration).To(gomega.Equal(5 * time.Second))
+	g.Expect(trigger.Window.WatermarkDelay.Duration).To(gomega.Equal(time.Minute))
+
+	err = json.Unmarshal([]byte(`{"window": {"duration": "ten"}}`), &trigger)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestTriggerUnmarshalMilliseconds(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// ModelMonitors stored before durations took strings
+	var modelMonitor ModelMonitor
+	err := json.Unmarshal([]byte(`{"spec": {"monitoring": {"trigger": {
+		"window": {"duration": 10000, "slide": 5000, "watermarkDelay": 4000}}}}}`), &modelMonitor)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	window := modelMonitor.Spec.Monitoring.Trigger.Window
+	g.Expect(window.Duration.Duration).To(gomega.Equal(10 * time.Second))
+	g.Expect(window.Slide.Duration).To(gomega.Equal(5 * time.Second))
+	g.Expect(window.WatermarkDelay.Duration).To(gomega.Equal(4 * time.Second))
+
+	var schedule ScheduleSpec
+	err = json.Unmarshal([]byte(`{"cron": "@hourly", "lookback": 3600000}`), &schedule)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(schedule.Lookback.Duration).To(gomega.Equal(time.Hour))
+}
+
+func TestDurationMarshal(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	data, err := json.Marshal(WindowSpec{Duration: Duration{Duration: 90 * time.Second}})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(data).To(gomega.MatchJSON(`{"duration": "1m30s", "watermarkDelay": "0s"}`))
+}
@@ -0,0 +1,202 @@
+package v1beta1
+
+import (
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ApplyDefaults sets the default values of all the optional fields left empty in the spec
+func (mm *ModelMonitor) ApplyDefaults() {
+	mm.Spec.Model.ApplyDefaults()
+	mm.Spec.Monitoring.ApplyDefaults(mm.Name)
+	mm.Spec.Storage.ApplyDefaults(mm.Name, mm.Spec.Model.Name)
+	mm.Spec.Job.ApplyDefaults()
+	mm.Spec.InferenceLogger.ApplyDefaults()
+}
+
+// ApplyDefaults sets the default values of the Model settings
+func (s *ModelSpec) ApplyDefaults() {
+	if s.Protocol == "" {
+		s.Protocol = V1InferenceProtocol
+	}
+	if s.OnVersionChange == "" {
+		s.OnVersionChange = NoneVersionChangePolicy
+	}
+}
+
+// ApplyDefaults sets the default values of the Monitoring settings
+func (s *MonitoringSpec) ApplyDefaults(modelMonitorName string) {
+	if s.Stats.Stddev != nil && s.Stats.Stddev.Type == "" {
+		s.Stats.Stddev.Type = constants.MonitoringStatsDefaultType
+	}
+	if s.Stats.Cov != nil && s.Stats.Cov.Type == "" {
+		s.Stats.Cov.Type = constants.MonitoringStatsDefaultType
+	}
+	if s.Stats.Corr != nil && s.Stats.Corr.Type == "" {
+		s.Stats.Corr.Type = constants.MonitoringStatsDefaultType
+	}
+	if s.PerRevision != nil && s.PerRevision.CompareCanary == nil {
+		compareCanary := s.Drift != nil
+		s.PerRevision.CompareCanary = &compareCanary
+	}
+	if s.ComputesBaselines() {
+		s.Baseline.ApplyComputedDefaults(modelMonitorName)
+	}
+	if s.Alerting != nil {
+		for i := range s.Alerting.Rules {
+			rule := &s.Alerting.Rules[i]
+			if rule.ConsecutiveWindows == 0 {
+				rule.ConsecutiveWindows = 1
+			}
+			if rule.Severity == "" {
+				rule.Severity = WarningAlertSeverity
+			}
+		}
+	}
+}
+
+// ApplyComputedDefaults sets the default dataset format and references the baselines left empty from the ConfigMap
+// written by the Baseline job
+func (s *BaselineSpec) ApplyComputedDefaults(modelMonitorName string) {
+	if s.Dataset != nil && s.Dataset.Format == "" {
+		s.Dataset.Format = ParquetDatasetFormat
+	}
+	configMapName := constants.DefaultBaselineConfigMapName(modelMonitorName)
+	if s.Descriptive == "" && s.DescriptiveFrom == nil {
+		s.DescriptiveFrom = configMapBaselineSource(configMapName, constants.BaselineDescriptiveKey)
+	}
+	if s.Distributions == "" && s.DistributionsFrom == nil {
+		s.DistributionsFrom = configMapBaselineSource(configMapName, constants.BaselineDistributionsKey)
+	}
+}
+
+// ApplyDefaults sets the default values of the Storage settings
+func (s *StorageSpec) ApplyDefaults(modelMonitorName string, modelName string) {
+	if s.Inference.Kafka.Topic.Name == "" {
+		s.Inference.Kafka.Topic.Name = constants.DefaultKafkaTopicName(modelName)
+	}
+	if s.DeletionPolicy == "" {
+		s.DeletionPolicy = RetainDeletionPolicy
+	}
+
+	for _, kafka := range s.KafkaSpecs() {
+		kafka.ApplyDefaults()
+	}
+	for _, sink := range s.Analysis.Sinks() {
+		if sink.S3 != nil && sink.S3.Format == "" {
+			sink.S3.Format = ParquetS3SinkFormat
+		}
+		if sink.Pushgateway != nil && sink.Pushgateway.Job == "" {
+			sink.Pushgateway.Job = constants.DefaultMonitoringJobName(modelMonitorName)
+		}
+	}
+}
+
+// ApplyDefaults sets the default values of the Kafka security settings
+func (s *KafkaSpec) ApplyDefaults() {
+	if s.SASL != nil && s.SASL.Mechanism == "" {
+		s.SASL.Mechanism = PlainSASLMechanism
+	}
+}
+
+// ApplyDefaults sets the default values of the Monitoring job driver and executors
+func (s *JobSpec) ApplyDefaults() {
+	// Driver
+	if s.Driver.Cores == 0 {
+		s.Driver.Cores = constants.MonitoringJobDriverCores
+	}
+	if s.Driver.CoreLimit == "" {
+		s.Driver.CoreLimit = constants.MonitoringJobDriverCoreLimit
+	}
+	if s.Driver.Memory == "" {
+		s.Driver.Memory = constants.MonitoringJobDriverMemory
+	}
+
+	// Executor
+	if s.Executor.Cores == 0 {
+		s.Executor.Cores = constants.MonitoringJobExecutorCores
+	}
+	if s.Executor.CoreLimit == "" {
+		s.Executor.CoreLimit = constants.MonitoringJobExecutorCoreLimit
+	}
+	if s.Executor.Memory == "" {
+		s.Executor.Memory = constants.MonitoringJobExecutorMemory
+	}
+	if s.Executor.Instances == 0 {
+		s.Executor.Instances = constants.MonitoringJobExecutorInstances
+	}
+
+	// Restarts
+	if s.RestartPolicy == nil {
+		s.RestartPolicy = &RestartPolicySpec{}
+	}
+	s.RestartPolicy.ApplyDefaults()
+}
+
+// ApplyDefaults sets the default values of the Monitoring job restart policy
+func (s *RestartPolicySpec) ApplyDefaults() {
+	if s.Type == "" {
+		s.Type = OnFailureRestartPolicy
+	}
+	if s.Retries == nil {
+		retries := constants.MonitoringJobRestartRetries
+		s.Retries = &retries
+	}
+	if s.Backoff.Duration == 0 {
+		s.Backoff.Duration = constants.MonitoringJobRestartBackoff
+	}
+	if s.MaxBackoff.Duration == 0 {
+		s.MaxBackoff.Duration = constants.MonitoringJobRestartMaxBackoff
+	}
+}
+
+// ApplyDefaults sets the default values of the InferenceLogger autoscaling and resources
+func (s *InferenceLoggerSpec) ApplyDefaults() {
+	// Autoscaling
+	if s.Autoscaler == "" {
+		s.Autoscaler = Autoscaler(constants.InferenceLoggerDefaultScalingClass)
+	}
+	if s.Metric == "" {
+		s.Metric = AutoscalerMetric(constants.InferenceLoggerDefaultScalingMetric)
+	}
+	if s.Target == 0 {
+		s.Target = constants.InferenceLoggerDefaultScalingTarget
+	}
+	if s.TargetUtilization == "" {
+		s.TargetUtilization = constants.InferenceLoggerDefaultTargetUtilizationPercentage
+	}
+	if s.Window == "" {
+		s.Window = constants.InferenceLoggerDefaultWindow
+	}
+	if s.PanicWindow == "" {
+		s.PanicWindow = constants.InferenceLoggerDefaultPanicWindow
+	}
+	if s.PanicThreshold == "" {
+		s.PanicThreshold = constants.InferenceLoggerDefaultPanicThreshold
+	}
+	if s.MinScale == 0 {
+		s.MinScale = constants.InferenceLoggerDefaultMinScale
+	}
+
+	// Resources
+	defaultResources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(constants.InferenceLoggerDefaultCPU),
+		corev1.ResourceMemory: resource.MustParse(constants.InferenceLoggerDefaultMemory),
+	}
+	if s.Resources.Requests == nil {
+		s.Resources.Requests = corev1.ResourceList{}
+	}
+	if s.Resources.Limits == nil {
+		s.Resources.Limits = corev1.ResourceList{}
+	}
+	for name, value := range defaultResources {
+		if _, ok := s.Resources.Requests[name]; !ok {
+			s.Resources.Requests[name] = value.DeepCopy()
+		}
+		if _, ok := s.Resources.Limits[name]; !ok {
+			s.Resources.Limits[name] = value.DeepCopy()
+		}
+	}
+}
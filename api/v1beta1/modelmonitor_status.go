@@ -0,0 +1,388 @@
+package v1beta1
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// ConditionType represents a ModelMonitor condition value
+const (
+	// ConfigReady is set when the ModelMonitor config has been loaded successfully.
+	ConfigReady ConditionType = "ConfigReady"
+	// StorageReady is set when the Kafka topics exist and the other sinks are reachable.
+	StorageReady ConditionType = "StorageReady"
+	// BaselineReady is set when the baselines referenced from ConfigMaps and Secrets exist and match the instance schema.
+	BaselineReady ConditionType = "BaselineReady"
+	// InferenceLoggerReady is set when the InferenceLogger Knative Service has reported readiness.
+	InferenceLoggerReady ConditionType = "InferenceLoggerReady"
+	// MonitoringJobReady is set when the Monitoring job Spark Application is running.
+	MonitoringJobReady ConditionType = "MonitoringJobReady"
+	// InferenceServiceLogged is set when the InferenceService predictors log to the InferenceLogger.
+	InferenceServiceLogged ConditionType = "InferenceServiceLogged"
+	// AlerterReady is set when the Alerter evaluating the alerting rules has available replicas.
+	AlerterReady ConditionType = "AlerterReady"
+)
+
+// ModelMonitorPhase values
+const (
+	ModelMonitorPending ModelMonitorPhase = "Pending"
+	ModelMonitorRunning ModelMonitorPhase = "Running"
+	ModelMonitorFailed  ModelMonitorPhase = "Failed"
+)
+
+// ModelMonitor is running when all the following conditions are true
+var readyConditions = []ConditionType{
+	ConfigReady,
+	StorageReady,
+	BaselineReady,
+	InferenceLoggerReady,
+	MonitoringJobReady,
+}
+
+// InitializeConditions sets unknown status to the conditions not yet observed
+func (ss *ModelMonitorStatus) InitializeConditions() {
+	for _, conditionType := range readyConditions {
+		if ss.GetCondition(conditionType) == nil {
+			ss.SetCondition(conditionType, corev1.ConditionUnknown, "", "")
+		}
+	}
+}
+
+// IsReady returns if all the ModelMonitor components are ready
+func (ss *ModelMonitorStatus) IsReady() bool {
+	for _, conditionType := range readyConditions {
+		if !ss.IsConditionTrue(conditionType) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsConditionTrue returns if a given condition is true
+func (ss *ModelMonitorStatus) IsConditionTrue(conditionType ConditionType) bool {
+	condition := ss.GetCondition(conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// GetCondition returns the condition by type
+func (ss *ModelMonitorStatus) GetCondition(conditionType ConditionType) *Condition {
+	for i := range ss.Conditions {
+		if ss.Conditions[i].Type == conditionType {
+			return &ss.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition sets the status of a condition. The transition time is only updated when the status changes.
+func (ss *ModelMonitorStatus) SetCondition(conditionType ConditionType, status corev1.ConditionStatus, reason string, message string) {
+	condition := ss.GetCondition(conditionType)
+	if condition == nil {
+		ss.Conditions = append(ss.Conditions, Condition{Type: conditionType})
+		condition = &ss.Conditions[len(ss.Conditions)-1]
+	}
+	if condition.Status != status {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	condition.Status = status
+	condition.Reason = reason
+	condition.Message = message
+	condition.ObservedGeneration = ss.ObservedGeneration
+
+	ss.updatePhase()
+}
+
+// ClearCondition removes a condition by type
+func (ss *ModelMonitorStatus) ClearCondition(conditionType ConditionType) {
+	for i := range ss.Conditions {
+		if ss.Conditions[i].Type == conditionType {
+			ss.Conditions = append(ss.Conditions[:i], ss.Conditions[i+1:]...)
+			break
+		}
+	}
+	ss.updatePhase()
+}
+
+// PropagateInferenceLoggerStatus propagates the status of the InferenceLogger Knative Service
+func (ss *ModelMonitorStatus) PropagateInferenceLoggerStatus(serviceStatus *knservingv1.ServiceStatus) {
+	if serviceStatus == nil {
+		ss.ClearCondition(InferenceLoggerReady)
+		ss.InferenceLogger = InferenceLoggerStatus{}
+		return
+	}
+
+	serviceCondition := serviceStatus.GetCondition(knservingv1.ServiceConditionReady)
+
+	switch {
+	case serviceCondition == nil:
+	case serviceCondition.Status == corev1.ConditionUnknown:
+		ss.SetCondition(InferenceLoggerReady, corev1.ConditionUnknown, serviceCondition.Reason, serviceCondition.Message)
+	case serviceCondition.Status == corev1.ConditionTrue:
+		ss.SetCondition(InferenceLoggerReady, corev1.ConditionTrue, "", "")
+	case serviceCondition.Status == corev1.ConditionFalse:
+		ss.SetCondition(InferenceLoggerReady, corev1.ConditionFalse, serviceCondition.Reason, serviceCondition.Message)
+	}
+
+	ss.InferenceLogger.URL = ""
+	if serviceStatus.URL != nil {
+		ss.InferenceLogger.URL = serviceStatus.URL.String()
+	}
+}
+
+// PropagateMonitoringJobStatus propagates the status of the Monitoring job Spark Application
+func (ss *ModelMonitorStatus) PropagateMonitoringJobStatus(appStatus *sparkv1beta2.SparkApplicationStatus) {
+	if appStatus == nil {
+		ss.ClearCondition(MonitoringJobReady)
+		ss.MonitoringJob = MonitoringJobStatus{}
+		return
+	}
+
+	state := appStatus.AppState.State
+	reason := string(state)
+
+	switch state {
+	case sparkv1beta2.RunningState:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionTrue, "", "")
+	case sparkv1beta2.FailedState, sparkv1beta2.FailedSubmissionState, sparkv1beta2.CompletedState:
+		// Terminated jobs waiting for a restart, see RestartPolicySpec
+		if ss.MonitoringJob.NextRestart != nil {
+			ss.SetCondition(MonitoringJobReady, corev1.ConditionUnknown, "Restarting", appStatus.AppState.ErrorMessage)
+			break
+		}
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionFalse, reason, appStatus.AppState.ErrorMessage)
+	default:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionUnknown, reason, appStatus.AppState.ErrorMessage)
+	}
+
+	ss.MonitoringJob.State = string(state)
+	ss.MonitoringJob.SparkApplicationID = appStatus.SparkApplicationID
+	ss.MonitoringJob.Schedule = nil
+}
+
+// PropagateMonitoringJobDeploymentStatus propagates the status of the Monitoring job Deployment on the deployment
+// backend, or a reason the job cannot run. The job is running while its replica is available.
+func (ss *ModelMonitorStatus) PropagateMonitoringJobDeploymentStatus(deploymentStatus *appsv1.DeploymentStatus, reason string, message string) {
+	modelRevision := ss.MonitoringJob.ModelRevision
+	switch {
+	case reason != "":
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionFalse, reason, message)
+		ss.MonitoringJob = MonitoringJobStatus{}
+	case deploymentStatus == nil:
+		ss.ClearCondition(MonitoringJobReady)
+		ss.MonitoringJob = MonitoringJobStatus{}
+	case deploymentStatus.AvailableReplicas > 0:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionTrue, "", "")
+		ss.MonitoringJob = MonitoringJobStatus{State: string(sparkv1beta2.RunningState), ModelRevision: modelRevision}
+	default:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionUnknown, "MonitoringJobUnavailable", "Waiting for the Monitoring job replica")
+		ss.MonitoringJob = MonitoringJobStatus{State: string(sparkv1beta2.SubmittedState), ModelRevision: modelRevision}
+	}
+}
+
+// PropagateScheduledMonitoringJobStatus propagates the status of the Monitoring job Scheduled Spark Application and
+// the Spark Application of its last run, nil if not found. The job is ready while scheduled, unless the last run failed.
+func (ss *ModelMonitorStatus) PropagateScheduledMonitoringJobStatus(scheduleStatus *sparkv1beta2.ScheduledSparkApplicationStatus,
+	lastRun *sparkv1beta2.SparkApplicationStatus) {
+
+	schedule := &ScheduledJobStatus{LastRunName: scheduleStatus.LastRunName}
+	if !scheduleStatus.LastRun.IsZero() {
+		schedule.LastRun = scheduleStatus.LastRun.DeepCopy()
+	}
+	if !scheduleStatus.NextRun.IsZero() {
+		schedule.NextRun = scheduleStatus.NextRun.DeepCopy()
+	}
+	ss.MonitoringJob.SparkApplicationID = ""
+	if lastRun != nil {
+		schedule.LastRunResult = string(lastRun.AppState.State)
+		ss.MonitoringJob.SparkApplicationID = lastRun.SparkApplicationID
+	}
+
+	switch {
+	case scheduleStatus.ScheduleState == sparkv1beta2.FailedValidationState:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionFalse, string(scheduleStatus.ScheduleState), scheduleStatus.Reason)
+	case scheduleStatus.ScheduleState != sparkv1beta2.ScheduledState:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionUnknown, "Scheduling", "")
+	case lastRun != nil && (lastRun.AppState.State == sparkv1beta2.FailedState || lastRun.AppState.State == sparkv1beta2.FailedSubmissionState):
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionFalse, "LastRunFailed", lastRun.AppState.ErrorMessage)
+	default:
+		ss.SetCondition(MonitoringJobReady, corev1.ConditionTrue, "", "")
+	}
+
+	ss.MonitoringJob.State = string(scheduleStatus.ScheduleState)
+	ss.MonitoringJob.Schedule = schedule
+	ss.MonitoringJob.Restarts = 0
+	ss.MonitoringJob.LastRestart = nil
+	ss.MonitoringJob.NextRestart = nil
+}
+
+// Finished returns whether the last run of a scheduled job finished, successfully or not
+func (s *ScheduledJobStatus) Finished() bool {
+	if s == nil {
+		return false
+	}
+	switch sparkv1beta2.ApplicationStateType(s.LastRunResult) {
+	case sparkv1beta2.CompletedState, sparkv1beta2.FailedState, sparkv1beta2.FailedSubmissionState:
+		return true
+	}
+	return false
+}
+
+// PropagateBaselineJobStatus propagates the observed state of the Baseline job computing the baselines from a source,
+// nil if baselines are not computed
+func (ss *ModelMonitorStatus) PropagateBaselineJobStatus(source string, appStatus *sparkv1beta2.SparkApplicationStatus) {
+	if appStatus == nil {
+		ss.BaselineJob = nil
+		return
+	}
+	if ss.BaselineJob == nil {
+		ss.BaselineJob = &BaselineJobStatus{}
+	}
+	ss.BaselineJob.State = string(appStatus.AppState.State)
+	ss.BaselineJob.SparkApplicationID = appStatus.SparkApplicationID
+	ss.BaselineJob.Source = source
+	ss.BaselineJob.CapturedAt = nil
+	if appStatus.AppState.State == sparkv1beta2.CompletedState && !appStatus.TerminationTime.IsZero() {
+		capturedAt := appStatus.TerminationTime
+		ss.BaselineJob.CapturedAt = &capturedAt
+	}
+}
+
+// RequestRebaseline records a re-baseline request made at the time given by the annotation value, returning whether
+// it was not handled yet
+func (ss *ModelMonitorStatus) RequestRebaseline(value string) (bool, error) {
+	if value == "" || (ss.BaselineJob != nil && ss.BaselineJob.Rebaseline == value) {
+		return false, nil
+	}
+	requestedAt, err := ParseRebaselineTime(value)
+	if err != nil {
+		return false, err
+	}
+	if ss.BaselineJob == nil {
+		ss.BaselineJob = &BaselineJobStatus{}
+	}
+	ss.BaselineJob.Rebaseline = value
+	ss.BaselineJob.RebaselinedAt = &requestedAt
+	return true, nil
+}
+
+// PropagateInferenceServiceStatus propagates the observed state of the InferenceService logger, nil if not found
+func (ss *ModelMonitorStatus) PropagateInferenceServiceStatus(name string, isvcStatus *InferenceServiceStatus) {
+	switch {
+	case isvcStatus == nil:
+		ss.InferenceService = InferenceServiceStatus{Name: name}
+		ss.SetCondition(InferenceServiceLogged, corev1.ConditionFalse, "InferenceServiceNotFound", "InferenceService "+name+" not found")
+	case isvcStatus.Mismatch != "":
+		ss.InferenceService = *isvcStatus
+		ss.SetCondition(InferenceServiceLogged, corev1.ConditionFalse, "LoggerMismatch", isvcStatus.Mismatch)
+	default:
+		ss.InferenceService = *isvcStatus
+		ss.SetCondition(InferenceServiceLogged, corev1.ConditionTrue, "", "")
+	}
+}
+
+// PropagateRevisionsStatus summarizes the revisions analysed separately from the observed InferenceService revisions,
+// with the canary revision compared against the default revision on the drift metrics if enabled. Nil per-revision
+// settings clear it.
+func (ss *ModelMonitorStatus) PropagateRevisionsStatus(monitoring *MonitoringSpec) {
+	ss.Revisions = nil
+	perRevision := monitoring.PerRevision
+	if perRevision == nil {
+		return
+	}
+
+	isvc := ss.InferenceService
+	ss.Revisions = append(ss.Revisions, RevisionStatus{Endpoint: DefaultEndpoint, Revision: isvc.Revision, Traffic: isvc.Traffic})
+	if isvc.CanaryRevision == "" {
+		return
+	}
+	canary := RevisionStatus{Endpoint: CanaryEndpoint, Revision: isvc.CanaryRevision, Traffic: isvc.CanaryTraffic}
+	if perRevision.CompareCanary != nil && *perRevision.CompareCanary && monitoring.Drift != nil {
+		canary.ComparedTo = DefaultEndpoint
+		canary.ConfiguredMetrics = monitoring.Drift.Metrics()
+	}
+	ss.Revisions = append(ss.Revisions, canary)
+}
+
+// PropagateBaselineStatus propagates the result of resolving the referenced baselines, ready if no reason is given
+func (ss *ModelMonitorStatus) PropagateBaselineStatus(reason string, message string) {
+	if reason == "" {
+		ss.SetCondition(BaselineReady, corev1.ConditionTrue, "", "")
+		return
+	}
+	ss.SetCondition(BaselineReady, corev1.ConditionFalse, reason, message)
+}
+
+// PropagateAlerterStatus propagates the status of the Alerter Deployment, or the reason it is not deployed
+func (ss *ModelMonitorStatus) PropagateAlerterStatus(name string, deploymentStatus *appsv1.DeploymentStatus, reason string, message string) {
+	switch {
+	case reason != "":
+		ss.SetCondition(AlerterReady, corev1.ConditionFalse, reason, message)
+		ss.Alerter = AlerterStatus{}
+	case deploymentStatus == nil:
+		ss.ClearCondition(AlerterReady)
+		ss.Alerter = AlerterStatus{}
+	case deploymentStatus.AvailableReplicas > 0:
+		ss.SetCondition(AlerterReady, corev1.ConditionTrue, "", "")
+		ss.Alerter = AlerterStatus{Name: name, ReadyReplicas: deploymentStatus.ReadyReplicas}
+	default:
+		ss.SetCondition(AlerterReady, corev1.ConditionUnknown, "AlerterUnavailable", "Waiting for the Alerter replicas")
+		ss.Alerter = AlerterStatus{Name: name, ReadyReplicas: deploymentStatus.ReadyReplicas}
+	}
+}
+
+// PropagateKafkaTopicsStatus propagates the observed state of the Kafka topics used as storage
+func (ss *ModelMonitorStatus) PropagateKafkaTopicsStatus(topics []KafkaTopicStatus) {
+	ss.Topics = topics
+	ss.updateStorageCondition()
+}
+
+// PropagateSinksStatus propagates the observed state of the non-Kafka analysis sinks
+func (ss *ModelMonitorStatus) PropagateSinksStatus(sinks []SinkStatus) {
+	ss.Sinks = sinks
+	ss.updateStorageCondition()
+}
+
+func (ss *ModelMonitorStatus) updateStorageCondition() {
+	var topicMismatches, sinkErrors []string
+	for _, topic := range ss.Topics {
+		if topic.Mismatch != "" {
+			topicMismatches = append(topicMismatches, topic.Name+": "+topic.Mismatch)
+		}
+	}
+	for _, sink := range ss.Sinks {
+		if sink.Error != "" {
+			sinkErrors = append(sinkErrors, sink.Name+": "+sink.Error)
+		}
+	}
+
+	// Topic mismatches don't prevent the job from using the topics, they are only reported
+	switch {
+	case len(sinkErrors) > 0:
+		ss.SetCondition(StorageReady, corev1.ConditionFalse, "SinkUnavailable", strings.Join(sinkErrors, "; "))
+	case len(topicMismatches) > 0:
+		ss.SetCondition(StorageReady, corev1.ConditionTrue, "TopicMismatch", strings.Join(topicMismatches, "; "))
+	default:
+		ss.SetCondition(StorageReady, corev1.ConditionTrue, "", "")
+	}
+}
+
+func (ss *ModelMonitorStatus) updatePhase() {
+	phase := ModelMonitorRunning
+	for _, conditionType := range readyConditions {
+		condition := ss.GetCondition(conditionType)
+		if condition != nil && condition.Status == corev1.ConditionFalse {
+			ss.Phase = ModelMonitorFailed
+			return
+		}
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			phase = ModelMonitorPending
+		}
+	}
+	ss.Phase = phase
+}
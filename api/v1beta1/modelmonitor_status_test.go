@@ -0,0 +1,190 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestModelMonitorStatusPhase(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+
+	status.InitializeConditions()
+	g.Expect(status.Conditions).To(gomega.HaveLen(len(readyConditions)))
+	g.Expect(status.Phase).To(gomega.Equal(ModelMonitorPending))
+	g.Expect(status.IsReady()).To(gomega.BeFalse())
+
+	for _, conditionType := range readyConditions {
+		status.SetCondition(conditionType, corev1.ConditionTrue, "", "")
+	}
+	g.Expect(status.Phase).To(gomega.Equal(ModelMonitorRunning))
+	g.Expect(status.IsReady()).To(gomega.BeTrue())
+
+	// Conditions outside the ready conditions don't change the phase
+	status.PropagateAlerterStatus("", nil, "AlerterConfigNotFound", "No alerter config")
+	g.Expect(status.Phase).To(gomega.Equal(ModelMonitorRunning))
+	status.PropagateInferenceServiceStatus("model", nil)
+	g.Expect(status.Phase).To(gomega.Equal(ModelMonitorRunning))
+	g.Expect(status.GetCondition(InferenceServiceLogged).Reason).To(gomega.Equal("InferenceServiceNotFound"))
+
+	status.PropagateBaselineStatus("BaselineNotFound", "ConfigMap not found")
+	g.Expect(status.Phase).To(gomega.Equal(ModelMonitorFailed))
+	g.Expect(status.GetCondition(BaselineReady).Reason).To(gomega.Equal("BaselineNotFound"))
+
+	// Initializing keeps the conditions observed
+	status.InitializeConditions()
+	g.Expect(status.IsConditionTrue(BaselineReady)).To(gomega.BeFalse())
+	g.Expect(status.Phase).To(gomega.Equal(ModelMonitorFailed))
+}
+
+func TestModelMonitorStatusSetCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{ObservedGeneration: 2}
+
+	status.SetCondition(MonitoringJobReady, corev1.ConditionUnknown, "SUBMITTED", "")
+	transition := metav1.Unix(1, 0)
+	status.GetCondition(MonitoringJobReady).LastTransitionTime = transition
+
+	// The transition time is kept while the status doesn't change
+	status.SetCondition(MonitoringJobReady, corev1.ConditionUnknown, "PENDING_RERUN", "")
+	condition := status.GetCondition(MonitoringJobReady)
+	g.Expect(condition.Reason).To(gomega.Equal("PENDING_RERUN"))
+	g.Expect(condition.LastTransitionTime).To(gomega.Equal(transition))
+	g.Expect(condition.ObservedGeneration).To(gomega.Equal(int64(2)))
+
+	status.SetCondition(MonitoringJobReady, corev1.ConditionTrue, "", "")
+	g.Expect(status.GetCondition(MonitoringJobReady).LastTransitionTime).NotTo(gomega.Equal(transition))
+
+	status.ClearCondition(MonitoringJobReady)
+	g.Expect(status.GetCondition(MonitoringJobReady)).To(gomega.BeNil())
+}
+
+func TestModelMonitorStatusStorageCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+
+	status.PropagateKafkaTopicsStatus([]KafkaTopicStatus{{Name: "inference"}})
+	g.Expect(status.IsConditionTrue(StorageReady)).To(gomega.BeTrue())
+
+	// Topic mismatches are reported without failing
+	status.PropagateKafkaTopicsStatus([]KafkaTopicStatus{{Name: "inference", Mismatch: "1 partitions, 2 expected"}})
+	condition := status.GetCondition(StorageReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionTrue))
+	g.Expect(condition.Reason).To(gomega.Equal("TopicMismatch"))
+	g.Expect(condition.Message).To(gomega.Equal("inference: 1 partitions, 2 expected"))
+
+	status.PropagateSinksStatus([]SinkStatus{{Name: "stats", Error: "connection refused"}})
+	condition = status.GetCondition(StorageReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal("SinkUnavailable"))
+	g.Expect(condition.Message).To(gomega.Equal("stats: connection refused"))
+}
+
+func TestModelMonitorStatusInferenceLoggerCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+
+	url, _ := apis.ParseURL("http://test-inferencelogger.default")
+	serviceStatus := &knservingv1.ServiceStatus{
+		Status: duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionFalse, Reason: "RevisionFailed"}}},
+	}
+	serviceStatus.URL = url
+	status.PropagateInferenceLoggerStatus(serviceStatus)
+	g.Expect(status.GetCondition(InferenceLoggerReady).Reason).To(gomega.Equal("RevisionFailed"))
+	g.Expect(status.InferenceLogger.URL).To(gomega.Equal("http://test-inferencelogger.default"))
+
+	status.PropagateInferenceLoggerStatus(nil)
+	g.Expect(status.GetCondition(InferenceLoggerReady)).To(gomega.BeNil())
+	g.Expect(status.InferenceLogger).To(gomega.Equal(InferenceLoggerStatus{}))
+}
+
+func TestModelMonitorStatusMonitoringJobCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+
+	appStatus := &sparkv1beta2.SparkApplicationStatus{SparkApplicationID: "spark-1"}
+	appStatus.AppState.State = sparkv1beta2.RunningState
+	status.PropagateMonitoringJobStatus(appStatus)
+	g.Expect(status.IsConditionTrue(MonitoringJobReady)).To(gomega.BeTrue())
+	g.Expect(status.MonitoringJob.SparkApplicationID).To(gomega.Equal("spark-1"))
+
+	appStatus.AppState.State = sparkv1beta2.FailedState
+	appStatus.AppState.ErrorMessage = "Driver pod failed"
+	status.PropagateMonitoringJobStatus(appStatus)
+	condition := status.GetCondition(MonitoringJobReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Message).To(gomega.Equal("Driver pod failed"))
+}
+
+func TestModelMonitorStatusAlerterCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+
+	status.PropagateAlerterStatus("alerter", &appsv1.DeploymentStatus{}, "", "")
+	g.Expect(status.GetCondition(AlerterReady).Status).To(gomega.Equal(corev1.ConditionUnknown))
+
+	status.PropagateAlerterStatus("alerter", &appsv1.DeploymentStatus{AvailableReplicas: 1, ReadyReplicas: 1}, "", "")
+	g.Expect(status.IsConditionTrue(AlerterReady)).To(gomega.BeTrue())
+	g.Expect(status.Alerter).To(gomega.Equal(AlerterStatus{Name: "alerter", ReadyReplicas: 1}))
+
+	// Alerting disabled
+	status.PropagateAlerterStatus("", nil, "", "")
+	g.Expect(status.GetCondition(AlerterReady)).To(gomega.BeNil())
+	g.Expect(status.Alerter).To(gomega.Equal(AlerterStatus{}))
+}
+
+func TestModelMonitorStatusRequestRebaseline(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+
+	requested, err := status.RequestRebaseline("")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(requested).To(gomega.BeFalse())
+
+	_, err = status.RequestRebaseline("yesterday")
+	g.Expect(err).To(gomega.MatchError(InvalidRebaselineError))
+	g.Expect(status.BaselineJob).To(gomega.BeNil())
+
+	requested, err = status.RequestRebaseline("2020-09-13T12:26:40Z")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(requested).To(gomega.BeTrue())
+	g.Expect(status.BaselineJob.RebaselinedAt.Time).To(gomega.BeTemporally("==", metav1.Unix(1600000000, 0).Time))
+
+	// Handled once
+	requested, err = status.RequestRebaseline("2020-09-13T12:26:40Z")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(requested).To(gomega.BeFalse())
+}
+
+func TestModelMonitorStatusRevisions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &ModelMonitorStatus{}
+	status.InferenceService = InferenceServiceStatus{Revision: "model-1", CanaryRevision: "model-2", Traffic: 80, CanaryTraffic: 20}
+	monitoring := &MonitoringSpec{}
+
+	status.PropagateRevisionsStatus(monitoring)
+	g.Expect(status.Revisions).To(gomega.BeNil())
+
+	compareCanary := false
+	monitoring.PerRevision = &PerRevisionSpec{CompareCanary: &compareCanary}
+	status.PropagateRevisionsStatus(monitoring)
+	g.Expect(status.Revisions).To(gomega.Equal([]RevisionStatus{
+		{Endpoint: DefaultEndpoint, Revision: "model-1", Traffic: 80},
+		{Endpoint: CanaryEndpoint, Revision: "model-2", Traffic: 20},
+	}))
+
+	compareCanary = true
+	monitoring.Drift = &DriftSpec{KullbackLeibler: &ThresholdBasedDriftSpec{Threshold: "0.5"}}
+	status.PropagateRevisionsStatus(monitoring)
+	g.Expect(status.Revisions[1].ComparedTo).To(gomega.Equal(DefaultEndpoint))
+	g.Expect(status.Revisions[1].ConfiguredMetrics).To(gomega.Equal([]string{"kullbackLeibler"}))
+}
@@ -43,23 +43,94 @@ type ModelSpec struct {
 	ID string `json:"id,omitempty"`
 	//+optional
 	Version *int `json:"version,omitempty"`
+	// Inference protocol of the model. Defaults to v1
+	//+optional
+	Protocol InferenceProtocol `json:"protocol,omitempty"`
+	// Input and output tensors of v2 protocol models, used to derive the schemas left empty
+	//+optional
+	Tensors *ModelTensorsSpec `json:"tensors,omitempty"`
+	// Request, response and instance schemas are required for v1 protocol models
+	//+optional
+	Schemas ModelSchemasSpec `json:"schemas,omitempty"`
+	// Monitoring job reaction to new revisions of the InferenceService default predictor. Defaults to None
+	//+optional
+	OnVersionChange VersionChangePolicy `json:"onVersionChange,omitempty"`
+}
+
+// VersionChangePolicy defines how the Monitoring job reacts to model version rollouts
+//+kubebuilder:validation:Enum=None;Restart;Rebaseline
+type VersionChangePolicy string
+
+// InferenceProtocol defines the KFServing data plane protocol of a model
+//+kubebuilder:validation:Enum=v1;v2
+type InferenceProtocol string
+
+// ModelTensorsSpec defines the tensor metadata of a v2 protocol model
+type ModelTensorsSpec struct {
 	//+required
-	Schemas ModelSchemasSpec `json:"schemas"`
+	Inputs []TensorSpec `json:"inputs"`
+	//+required
+	Outputs []TensorSpec `json:"outputs"`
+}
+
+// TensorSpec defines a named tensor of the v2 protocol
+type TensorSpec struct {
+	//+required
+	Name string `json:"name"`
+	//+required
+	Datatype TensorDatatype `json:"datatype"`
+	// Dimensions of the tensor, the first one being the batch dimension. Variable-size dimensions are -1
+	//+optional
+	Shape []int64 `json:"shape,omitempty"`
+	// Names of the features along the second dimension of [batch, features] tensors, derived as separate fields
+	//+optional
+	Features []string `json:"features,omitempty"`
 }
 
+// TensorDatatype defines the datatype of a v2 protocol tensor
+//+kubebuilder:validation:Enum=BOOL;UINT8;UINT16;UINT32;UINT64;INT8;INT16;INT32;INT64;FP16;FP32;FP64;BYTES
+type TensorDatatype string
+
 // ModelSchemasSpec defines the inference schema of a model
 type ModelSchemasSpec struct {
 	//+required
-	Request string `json:"request"`
+	Request Schema `json:"request"`
+	//+required
+	Response Schema `json:"response"`
+
+	//+required
+	Instance Schema `json:"instance"`
 	//+required
-	Response string `json:"response"`
+	Prediction Schema `json:"prediction"`
+}
+
+// Schema defines a Spark StructType. Spark StructType JSON strings are still accepted, converted by the defaulting webhook.
+type Schema struct {
+	//+optional
+	Fields []SchemaField `json:"fields,omitempty"`
+}
 
+// SchemaField defines a field of a Schema
+type SchemaField struct {
 	//+required
-	Instance string `json:"instance"`
+	Name string `json:"name"`
+	// Spark SQL data type (e.g double, string, array<array<double>>, map<string,long>, struct<a:int,b:string>)
 	//+required
-	Prediction string `json:"prediction"`
+	Type string `json:"type"`
+	// Defaults to true
+	//+optional
+	Nullable *bool `json:"nullable,omitempty"`
+	//+optional
+	Kind FeatureKind `json:"kind,omitempty"`
+	// Allowed values of a categorical feature
+	//+optional
+	Values []string `json:"values,omitempty"`
 }
 
+// FeatureKind defines whether a feature is categorical or numerical
+//+kubebuilder:validation:Enum=categorical;numerical
+type FeatureKind string
+
 // MonitoringSpec defines the Monitoring settings
 type MonitoringSpec struct {
 	//+required
@@ -72,22 +143,69 @@ type MonitoringSpec struct {
 	Outliers *OutlierSpec `json:"outliers,omitempty"`
 	//+optional
 	Drift *DriftSpec `json:"drift,omitempty"`
+	// Partitions the analysis by InferenceService revision, identified by the endpoint (default or canary) of the
+	// CloudEvents logged by KFServing
+	//+optional
+	PerRevision *PerRevisionSpec `json:"perRevision,omitempty"`
+	// Alerting rules evaluated on the drift and outlier results, by an Alerter deployed along with the job
+	//+optional
+	Alerting *AlertingSpec `json:"alerting,omitempty"`
+}
+
+// PerRevisionSpec defines the per-revision analysis of InferenceServices with canaries
+type PerRevisionSpec struct {
+	// Detects drift of the canary revision against the default revision. Defaults to true if drift is enabled
+	//+optional
+	CompareCanary *bool `json:"compareCanary,omitempty"`
 }
 
-// TriggerSpec defines the Monitoring trigger setting
+// TriggerSpec defines the Monitoring trigger setting. Exactly one trigger must be set: a time or count window runs
+// the job as an always-on streaming application, a schedule runs it periodically as a batch application.
 type TriggerSpec struct {
-	//+required
-	Window WindowSpec `json:"window"`
+	//+optional
+	Window *WindowSpec `json:"window,omitempty"`
+	//+optional
+	Count *CountWindowSpec `json:"count,omitempty"`
+	//+optional
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+	// Minimum inferences in a window (or run) for it to be analysed, smaller ones are skipped
+	//+optional
+	//+kubebuilder:validation:Minimum=0
+	MinSamples int64 `json:"minSamples,omitempty"`
 }
 
-// WindowSpec defines a Window as Monitoring job trigger
+// WindowSpec defines a time Window as Monitoring job trigger. Windows are tumbling if the slide is not set. Session
+// windows, closed after a gap without inferences, are not supported.
 type WindowSpec struct {
+	// Window length (e.g 10s, 5m)
+	//+required
+	Duration Duration `json:"duration"`
+	//+optional
+	Slide *Duration `json:"slide,omitempty"`
+	//+optional
+	WatermarkDelay Duration `json:"watermarkDelay,omitempty"`
+}
+
+// CountWindowSpec defines a Window of a number of inferences as Monitoring job trigger. Windows are tumbling if the
+// slide is not set.
+type CountWindowSpec struct {
 	//+required
-	Duration int `json:"duration"`
+	//+kubebuilder:validation:Minimum=1
+	Size int64 `json:"size"`
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	Slide int64 `json:"slide,omitempty"`
+}
+
+// ScheduleSpec defines a schedule as Monitoring job trigger. Each run analyses the inferences logged in the lookback
+// range before its start.
+type ScheduleSpec struct {
+	// Cron expression (e.g "0 * * * *") or descriptor (e.g "@hourly", "@every 30m")
 	//+required
-	Slide int `json:"slide"`
+	Cron string `json:"cron"`
+	// Range of inferences analysed by each run (e.g 1h)
 	//+required
-	WatermarkDelay int `json:"watermarkDelay"`
+	Lookback Duration `json:"lookback"`
 }
 
 // StatSpec defines a Statistic
@@ -118,6 +236,10 @@ type StatSpec struct {
 	Cov *CovSpec `json:"cov,omitempty"`
 	//+optional
 	Corr *CorrSpec `json:"corr,omitempty"`
+
+	// Instance features to compute the stats on, all if empty
+	//+optional
+	Features []string `json:"features,omitempty"`
 }
 
 // MaxSpec defines a Max stat
@@ -184,10 +306,61 @@ type CorrSpec struct {
 
 // BaselineSpec defines Baseline stats
 type BaselineSpec struct {
+	// Inline JSON object with the descriptive stats of each feature. Prefer descriptiveFrom for large baselines
 	//+optional
 	Descriptive string `json:"descriptive,omitempty"`
+	// Inline JSON object with the distribution of each feature. Prefer distributionsFrom for large baselines
 	//+optional
 	Distributions string `json:"distributions,omitempty"`
+	//+optional
+	DescriptiveFrom *BaselineSource `json:"descriptiveFrom,omitempty"`
+	//+optional
+	DistributionsFrom *BaselineSource `json:"distributionsFrom,omitempty"`
+	// Training dataset to compute the baselines left empty from, by a one-off Baseline job
+	//+optional
+	Dataset *BaselineDatasetSpec `json:"dataset,omitempty"`
+	// Inference traffic to capture the baselines left empty from, by a one-off Baseline job reading the inference topic
+	//+optional
+	Traffic *TrafficBaselineSpec `json:"traffic,omitempty"`
+}
+
+// TrafficBaselineSpec defines the inference traffic captured as baseline, either the first trigger windows or a time range
+type TrafficBaselineSpec struct {
+	// Number of trigger windows captured from the start of the inference topic, or from the last re-baseline
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	Windows int32 `json:"windows,omitempty"`
+	//+optional
+	From *metav1.Time `json:"from,omitempty"`
+	//+optional
+	To *metav1.Time `json:"to,omitempty"`
+}
+
+// BaselineDatasetSpec defines a training dataset, read by the Baseline job
+type BaselineDatasetSpec struct {
+	// Object store URI of the dataset (e.g s3a://bucket/iris/train.csv)
+	//+required
+	URI string `json:"uri"`
+	//+optional
+	Format DatasetFormat `json:"format,omitempty"`
+	// Spark data source options (e.g header: "true" for csv datasets)
+	//+optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// DatasetFormat defines the format of a training dataset
+//+kubebuilder:validation:Enum=parquet;csv;json
+type DatasetFormat string
+
+// BaselineSource references a baseline JSON object. Exactly one source must be set
+type BaselineSource struct {
+	//+optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	//+optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// Object store URI (e.g s3a://bucket/baseline.json), read by the Monitoring job
+	//+optional
+	URI string `json:"uri,omitempty"`
 }
 
 // OutlierSpec defines an Outlier detector
@@ -204,6 +377,10 @@ type DriftSpec struct {
 	KullbackLeibler *ThresholdBasedDriftSpec `json:"kullbackLeibler,omitempty"`
 	//+optional
 	JensenShannon *ThresholdBasedDriftSpec `json:"jensenShannon,omitempty"`
+
+	// Instance features to detect drift on, all if empty
+	//+optional
+	Features []string `json:"features,omitempty"`
 }
 
 // ThresholdBasedDriftSpec defines a threshold-based Drift detector
@@ -214,14 +391,95 @@ type ThresholdBasedDriftSpec struct {
 	ShowAll bool `json:"showAll,omitempty"`
 }
 
+// AlertingSpec defines the alerting rules and the receivers notified when they fire or resolve
+type AlertingSpec struct {
+	//+required
+	Rules []AlertRuleSpec `json:"rules"`
+	//+required
+	Receivers []AlertReceiverSpec `json:"receivers"`
+}
+
+// AlertRuleSpec defines an alerting rule on the drift or outlier results. Results whose value exceeds the threshold
+// alert, and the rule fires after consecutive alerting windows of the same feature.
+type AlertRuleSpec struct {
+	//+required
+	Name string `json:"name"`
+	// Analysis results the rule is evaluated on, read from the Kafka sink of storage.analysis
+	//+required
+	Source AlertSource `json:"source"`
+	// Drift detector (e.g jensenShannon) or outlier stat (e.g max) of the results, all if empty
+	//+optional
+	Detector string `json:"detector,omitempty"`
+	// Instance features the rule applies to, all if empty
+	//+optional
+	Features []string `json:"features,omitempty"`
+	//+required
+	Threshold string `json:"threshold"`
+	// Consecutive alerting windows before firing, 1 by default
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	ConsecutiveWindows int32 `json:"consecutiveWindows,omitempty"`
+	//+optional
+	Severity AlertSeverity `json:"severity,omitempty"`
+	// Names of the receivers notified, all if empty
+	//+optional
+	Receivers []string `json:"receivers,omitempty"`
+}
+
+// AlertSource defines the analysis results an alerting rule is evaluated on
+//+kubebuilder:validation:Enum=drift;outliers
+type AlertSource string
+
+// AlertSeverity defines the severity of the alerts of a rule
+//+kubebuilder:validation:Enum=info;warning;critical
+type AlertSeverity string
+
+// AlertReceiverSpec defines an alert receiver. Exactly one receiver kind must be set.
+type AlertReceiverSpec struct {
+	//+required
+	Name string `json:"name"`
+	//+optional
+	Alertmanager *AlertmanagerReceiverSpec `json:"alertmanager,omitempty"`
+	//+optional
+	Slack *SlackReceiverSpec `json:"slack,omitempty"`
+	//+optional
+	Events *EventsReceiverSpec `json:"events,omitempty"`
+}
+
+// AlertmanagerReceiverSpec defines a Prometheus Alertmanager receiving the alerts through its v2 API
+type AlertmanagerReceiverSpec struct {
+	// Alertmanager URL (e.g http://alertmanager.monitoring:9093)
+	//+required
+	URL string `json:"url"`
+}
+
+// SlackReceiverSpec defines a Slack-compatible incoming webhook
+type SlackReceiverSpec struct {
+	// Incoming webhook URL, kept in a Secret
+	//+required
+	URL corev1.SecretKeySelector `json:"url"`
+	//+optional
+	Channel string `json:"channel,omitempty"`
+}
+
+// EventsReceiverSpec defines Kubernetes Events on the ModelMonitor as alert receiver
+type EventsReceiverSpec struct {
+}
+
 // StorageSpec defines the Storage settings
 type StorageSpec struct {
 	//+required
-	Inference SinkSpec `json:"inference"`
+	Inference InferenceSinkSpec `json:"inference"`
 	//+required
 	Analysis AnalysisSpec `json:"analysis"`
+	//+optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy defines whether the storage is deleted along with the ModelMonitor
+//+kubebuilder:validation:Enum=Retain;Delete
+type DeletionPolicy string
+
 // AnalysisSpec defines the Analysis storage
 type AnalysisSpec struct {
 	//+required
@@ -232,24 +490,125 @@ type AnalysisSpec struct {
 	Drift *SinkSpec `json:"drift,omitempty"`
 }
 
-//SinkSpec defines the configuration of a Sink
-type SinkSpec struct {
+// InferenceSinkSpec defines the storage of the inference logs, written to Kafka by the InferenceLogger
+type InferenceSinkSpec struct {
 	//+required
 	Kafka KafkaSpec `json:"kafka"`
 }
 
+// SinkSpec defines the configuration of a Sink. Exactly one sink kind must be set.
+type SinkSpec struct {
+	//+optional
+	Kafka *KafkaSpec `json:"kafka,omitempty"`
+	//+optional
+	S3 *S3SinkSpec `json:"s3,omitempty"`
+	//+optional
+	Pushgateway *PushgatewaySinkSpec `json:"pushgateway,omitempty"`
+	//+optional
+	JDBC *JDBCSinkSpec `json:"jdbc,omitempty"`
+	//+optional
+	Webhook *WebhookSinkSpec `json:"webhook,omitempty"`
+}
+
+// S3SinkSpec defines an S3-compatible object store sink
+type S3SinkSpec struct {
+	// Endpoint of S3-compatible stores (e.g MinIO), AWS S3 if empty
+	//+optional
+	Endpoint string `json:"endpoint,omitempty"`
+	//+optional
+	Region string `json:"region,omitempty"`
+	//+required
+	Bucket string `json:"bucket"`
+	//+optional
+	Prefix string `json:"prefix,omitempty"`
+	//+optional
+	Format S3SinkFormat `json:"format,omitempty"`
+	//+optional
+	PathStyleAccess bool `json:"pathStyleAccess,omitempty"`
+	// Access key id, requires secretAccessKey
+	//+optional
+	AccessKeyID *corev1.SecretKeySelector `json:"accessKeyId,omitempty"`
+	// Secret access key, requires accessKeyId
+	//+optional
+	SecretAccessKey *corev1.SecretKeySelector `json:"secretAccessKey,omitempty"`
+}
+
+// S3SinkFormat defines the format of the objects written to S3
+//+kubebuilder:validation:Enum=parquet;json
+type S3SinkFormat string
+
+// PushgatewaySinkSpec defines a Prometheus Pushgateway sink
+type PushgatewaySinkSpec struct {
+	//+required
+	URL string `json:"url"`
+	//+optional
+	Job string `json:"job,omitempty"`
+}
+
+// JDBCSinkSpec defines a JDBC database sink
+type JDBCSinkSpec struct {
+	//+required
+	URL string `json:"url"`
+	//+required
+	Table string `json:"table"`
+	//+optional
+	Driver string `json:"driver,omitempty"`
+	// Username, requires password
+	//+optional
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+	// Password, requires username
+	//+optional
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+}
+
+// WebhookSinkSpec defines an HTTP webhook sink receiving the analysis results as JSON POST requests
+type WebhookSinkSpec struct {
+	//+required
+	URL string `json:"url"`
+}
+
 // KafkaSpec defines the KafkaTopic used for inference logging.
 type KafkaSpec struct {
 	//+required
 	Brokers string `json:"brokers"`
 	//+required
 	Topic KafkaTopicSpec `json:"topic"`
+	//+optional
+	TLS *KafkaTLSSpec `json:"tls,omitempty"`
+	//+optional
+	SASL *KafkaSASLSpec `json:"sasl,omitempty"`
 }
 
+// KafkaTLSSpec enables TLS to connect to the brokers. The CA defaults to the system CAs.
+type KafkaTLSSpec struct {
+	//+optional
+	CACert *corev1.SecretKeySelector `json:"caCert,omitempty"`
+	// Client certificate, requires key
+	//+optional
+	Cert *corev1.SecretKeySelector `json:"cert,omitempty"`
+	// Client private key, requires cert
+	//+optional
+	Key *corev1.SecretKeySelector `json:"key,omitempty"`
+}
+
+// KafkaSASLSpec enables SASL authentication to connect to the brokers
+type KafkaSASLSpec struct {
+	//+optional
+	Mechanism KafkaSASLMechanism `json:"mechanism,omitempty"`
+	//+required
+	Username corev1.SecretKeySelector `json:"username"`
+	//+required
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// KafkaSASLMechanism defines the SASL mechanism
+//+kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+type KafkaSASLMechanism string
+
 // KafkaTopicSpec defines a Kafka topic
 type KafkaTopicSpec struct {
-	//+required
-	Name string `json:"name"`
+	//+optional
+	Name string `json:"name,omitempty"`
 	//+optional
 	Partitions int32 `json:"partitions,omitempty"`
 	//+optional
@@ -266,8 +625,41 @@ type JobSpec struct {
 	Driver DriverSpec `json:"driver,omitempty"`
 	//+optional
 	Executor ExecutorSpec `json:"executor,omitempty"`
+	// Restarts of the streaming job once terminated. Defaults to restarting failed jobs
+	//+optional
+	RestartPolicy *RestartPolicySpec `json:"restartPolicy,omitempty"`
+	// Volumes of the job pods (e.g emptyDir, a PersistentVolumeClaim for checkpoints, a ConfigMap or a Secret)
+	//+optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// Mounts of the volumes in the driver and executors of the Monitoring job, not in the Baseline job
+	//+optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Location of the streaming checkpoints, so that the job state survives restarts. Either a path in a volume mount
+	// shared by the driver and executors (e.g a ReadWriteMany PersistentVolumeClaim) or a URI (e.g s3a://bucket/checkpoints)
+	//+optional
+	CheckpointLocation string `json:"checkpointLocation,omitempty"`
+}
+
+// RestartPolicySpec defines when and how often the streaming Monitoring job is restarted once terminated. Scheduled
+// runs are not restarted.
+type RestartPolicySpec struct {
+	//+optional
+	Type RestartPolicyType `json:"type,omitempty"`
+	// Consecutive restarts before giving up
+	//+optional
+	Retries *int32 `json:"retries,omitempty"`
+	// Delay before the first restart, doubled on every consecutive restart (e.g 10s)
+	//+optional
+	Backoff Duration `json:"backoff,omitempty"`
+	// Maximum delay between restarts (e.g 5m)
+	//+optional
+	MaxBackoff Duration `json:"maxBackoff,omitempty"`
 }
 
+// RestartPolicyType defines the terminal states of the Monitoring job that trigger a restart
+//+kubebuilder:validation:Enum=Never;OnFailure;Always
+type RestartPolicyType string
+
 // ResourcesSpec defines resources configuration
 type ResourcesSpec struct {
 	//+optional
@@ -326,12 +718,200 @@ type AutoscalerMetric string
 
 // ModelMonitorStatus defines the observed state of ModelMonitor
 type ModelMonitorStatus struct {
-	// TODO: Add Model Monitor status
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//+optional
+	Phase ModelMonitorPhase `json:"phase,omitempty"`
+	//+optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	//+optional
+	ConfigRevision string `json:"configRevision,omitempty"`
+	//+optional
+	InferenceLogger InferenceLoggerStatus `json:"inferenceLogger,omitempty"`
+	//+optional
+	MonitoringJob MonitoringJobStatus `json:"monitoringJob,omitempty"`
+	//+optional
+	InferenceService InferenceServiceStatus `json:"inferenceService,omitempty"`
+	//+optional
+	Topics []KafkaTopicStatus `json:"topics,omitempty"`
+	//+optional
+	Sinks []SinkStatus `json:"sinks,omitempty"`
+	// Revisions analysed separately, when the analysis is partitioned by revision
+	//+optional
+	Revisions []RevisionStatus `json:"revisions,omitempty"`
+	// Baseline job computing the baselines from the training dataset
+	//+optional
+	BaselineJob *BaselineJobStatus `json:"baselineJob,omitempty"`
+	//+optional
+	Alerter AlerterStatus `json:"alerter,omitempty"`
+}
+
+// ModelMonitorPhase defines the phase of a ModelMonitor
+//+kubebuilder:validation:Enum=Pending;Running;Failed
+type ModelMonitorPhase string
+
+// Condition defines an observation of a ModelMonitor component state
+type Condition struct {
+	//+required
+	Type ConditionType `json:"type"`
+	//+required
+	Status corev1.ConditionStatus `json:"status"`
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//+optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	//+optional
+	Reason string `json:"reason,omitempty"`
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// ConditionType defines the type of a ModelMonitor condition
+type ConditionType string
+
+// InferenceLoggerStatus defines the observed state of the InferenceLogger
+type InferenceLoggerStatus struct {
+	//+optional
+	URL string `json:"url,omitempty"`
+}
+
+// MonitoringJobStatus defines the observed state of the Monitoring job
+type MonitoringJobStatus struct {
+	//+optional
+	State string `json:"state,omitempty"`
+	//+optional
+	SparkApplicationID string `json:"sparkApplicationId,omitempty"`
+	// InferenceService revision the job was started for, unless version changes are ignored
+	//+optional
+	ModelRevision string `json:"modelRevision,omitempty"`
+	// Runs of the job, if scheduled
+	//+optional
+	Schedule *ScheduledJobStatus `json:"schedule,omitempty"`
+	// Consecutive restarts of the streaming job
+	//+optional
+	Restarts int32 `json:"restarts,omitempty"`
+	//+optional
+	LastRestart *metav1.Time `json:"lastRestart,omitempty"`
+	// Time the terminated job is restarted at, after the backoff
+	//+optional
+	NextRestart *metav1.Time `json:"nextRestart,omitempty"`
+}
+
+// ScheduledJobStatus defines the observed state of the runs of a scheduled Monitoring job
+type ScheduledJobStatus struct {
+	//+optional
+	LastRun *metav1.Time `json:"lastRun,omitempty"`
+	//+optional
+	NextRun *metav1.Time `json:"nextRun,omitempty"`
+	// Name of the Spark Application of the last run
+	//+optional
+	LastRunName string `json:"lastRunName,omitempty"`
+	// State of the Spark Application of the last run (e.g COMPLETED, FAILED)
+	//+optional
+	LastRunResult string `json:"lastRunResult,omitempty"`
+}
+
+// AlerterStatus defines the observed state of the Alerter
+type AlerterStatus struct {
+	//+optional
+	Name string `json:"name,omitempty"`
+	//+optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
+// BaselineJobStatus defines the observed state of the Baseline job
+type BaselineJobStatus struct {
+	//+optional
+	State string `json:"state,omitempty"`
+	//+optional
+	SparkApplicationID string `json:"sparkApplicationId,omitempty"`
+	// Dataset URI or inference topic the baselines are computed from
+	//+optional
+	Source string `json:"source,omitempty"`
+	// When the baselines were captured, i.e the Baseline job completed
+	//+optional
+	CapturedAt *metav1.Time `json:"capturedAt,omitempty"`
+	// Value of the re-baseline annotation last handled, and when it was
+	//+optional
+	Rebaseline string `json:"rebaseline,omitempty"`
+	//+optional
+	RebaselinedAt *metav1.Time `json:"rebaselinedAt,omitempty"`
+}
+
+// InferenceServiceStatus defines the observed state of the logger of the monitored InferenceService
+type InferenceServiceStatus struct {
+	//+optional
+	Name string `json:"name,omitempty"`
+	// URL the predictors log to
+	//+optional
+	LoggerURL string `json:"loggerUrl,omitempty"`
+	//+optional
+	Mismatch string `json:"mismatch,omitempty"`
+	// Latest ready revision of the default predictor
+	//+optional
+	Revision string `json:"revision,omitempty"`
+	// Latest ready revision of the canary predictor
+	//+optional
+	CanaryRevision string `json:"canaryRevision,omitempty"`
+	// Traffic percentage of the default predictor
+	//+optional
+	Traffic int `json:"traffic,omitempty"`
+	// Traffic percentage of the canary predictor
+	//+optional
+	CanaryTraffic int `json:"canaryTraffic,omitempty"`
+}
+
+// RevisionStatus defines an InferenceService revision analysed separately
+type RevisionStatus struct {
+	// InferenceService endpoint, default or canary
+	//+required
+	Endpoint string `json:"endpoint"`
+	//+optional
+	Revision string `json:"revision,omitempty"`
+	// Traffic percentage of the revision
+	//+optional
+	Traffic int `json:"traffic,omitempty"`
+	// Endpoint the revision drift is detected against, if any
+	//+optional
+	ComparedTo string `json:"comparedTo,omitempty"`
+	// Drift metrics configured for the comparison against the compared endpoint. They are taken from the spec and don't
+	// confirm the job computed them.
+	//+optional
+	ConfiguredMetrics []string `json:"configuredMetrics,omitempty"`
+}
+
+// KafkaTopicStatus defines the observed state of a Kafka topic used as storage
+type KafkaTopicStatus struct {
+	//+required
+	Name string `json:"name"`
+	//+required
+	Brokers string `json:"brokers"`
+	//+optional
+	Partitions int32 `json:"partitions,omitempty"`
+	//+optional
+	ReplicationFactor int16 `json:"replicationFactor,omitempty"`
+	//+optional
+	Mismatch string `json:"mismatch,omitempty"`
+}
+
+// SinkStatus defines the observed state of a non-Kafka analysis sink
+type SinkStatus struct {
+	// Path of the sink in the spec (e.g analysis.stats)
+	//+required
+	Name string `json:"name"`
+	//+required
+	Type string `json:"type"`
+	//+optional
+	Error string `json:"error,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=modelmonitors,shortName=modelmonitor
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Logger URL",type="string",JSONPath=".status.inferenceLogger.url"
+// +kubebuilder:printcolumn:name="Job State",type="string",JSONPath=".status.monitoringJob.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ModelMonitor is the Schema for the modelmonitors API
 type ModelMonitor struct {
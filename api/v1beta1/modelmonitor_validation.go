@@ -0,0 +1,725 @@
+package v1beta1
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Known error messages
+const (
+	MissingFieldNameError              = "field name is required"
+	InvalidFieldTypeError              = "must be a Spark SQL data type: %v"
+	ValuesOfNonCategoricalFeatureError = "allowed values can only be set on categorical features"
+	NonPositiveWindowError             = "must be greater than 0"
+	NegativeWatermarkDelayError        = "must be greater than or equal to 0"
+	SlideGreaterThanDurationError      = "slide cannot be greater than the window duration"
+	SlideGreaterThanSizeError          = "slide cannot be greater than the window size"
+	NonNumericThresholdError           = "threshold must be numeric"
+	NonNumericPercentileError          = "percentile must be numeric"
+	PercentileOutOfBoundsError         = "percentile must be between [0, 100]"
+	OutlierStatNotEnabledError         = "stat must be enabled in monitoring.stats"
+	UnsupportedSparkMemoryError        = "must be a Spark memory string (e.g 512m, 1g)"
+	MissingTopicNameError              = "topic name is required"
+	MissingSecretKeySelectorError      = "secret name and key are required"
+	IncompleteCredentialsError         = "%s and %s must be set together"
+	InvalidSinkTypeError               = "exactly one sink type must be set"
+	MissingBucketError                 = "bucket is required"
+	MissingTableError                  = "table is required"
+	InvalidJDBCURLError                = "must be a JDBC URL (e.g jdbc:postgresql://host:5432/db)"
+	InvalidHTTPURLError                = "must be an http or https URL"
+	TensorsOfV1ModelError              = "tensors can only be set on v2 protocol models"
+	MissingTensorsError                = "tensors are required for v2 protocol models"
+	MissingV1SchemaError               = "schema fields are required for v1 protocol models"
+	MissingTensorNameError             = "tensor name is required"
+	InvalidTensorDimensionError        = "must be greater than 0, or -1 for variable-size dimensions"
+	FeaturesOfNon2DTensorError         = "features can only be set on [batch, features] tensors"
+	FeaturesShapeMismatchError         = "must match the size of the second dimension (%d)"
+	CompareCanaryWithoutDriftError     = "drift must be enabled in monitoring.drift to compare the canary revision"
+	InlineAndReferencedBaselineError   = "%s and %sFrom cannot be set together"
+	InvalidBaselineSourceError         = "exactly one of configMapKeyRef, secretKeyRef or uri must be set"
+	MissingConfigMapKeySelectorError   = "config map name and key are required"
+	MissingDatasetURIError             = "dataset uri is required"
+	DatasetAndTrafficBaselineError     = "dataset and traffic cannot be set together"
+	InvalidTrafficBaselineError        = "either windows or from and to must be set"
+	InvalidTrafficTimeRangeError       = "must be after from"
+	MissingAlertRuleNameError          = "rule name is required"
+	MissingAlertReceiverNameError      = "receiver name is required"
+	AlertSourceNotKafkaError           = "storage.analysis.%s must be a Kafka sink to alert on its results"
+	AlertDetectorNotEnabledError       = "detector must be enabled in monitoring.%s"
+	UnknownAlertReceiverError          = "receiver not defined in alerting.receivers"
+	InvalidAlertReceiverTypeError      = "exactly one receiver type must be set"
+	InvalidTriggerError                = "exactly one of window, count or schedule must be set"
+	InvalidCronError                   = "must be a cron expression with 5 fields or a descriptor (e.g @hourly, @every 30m)"
+	NegativeValueError                 = "must be greater than or equal to 0"
+	MaxBackoffLessThanBackoffError     = "maxBackoff cannot be less than the backoff"
+	MissingVolumeNameError             = "volume name is required"
+	ReservedVolumeNameError            = "volume names starting with secret- or configmap- are reserved"
+	CheckpointNotMountedError          = "local checkpoint locations must be in a volume mount"
+	InvalidRebaselineError             = "must be a Unix timestamp in seconds or an RFC 3339 time"
+	UnableToValidateModelMonitorError  = "Unable to validate, ModelMonitor is nil"
+)
+
+// Cron fields are lists of values, ranges and steps, with names for months and days of week (e.g */15, 1-5, MON,WED)
+var cronFieldRegexp = regexp.MustCompile(`^(\*|\?|[0-9A-Za-z]+(-[0-9A-Za-z]+)?)(/[0-9]+)?(,(\*|[0-9A-Za-z]+(-[0-9A-Za-z]+)?)(/[0-9]+)?)*$`)
+
+// Spark memory strings are numbers followed by an optional size unit (e.g 512m, 2g, 1024kb)
+var sparkMemoryRegex = regexp.MustCompile(`(?i)^[0-9]+([kmgtp]b?|b)?$`)
+
+// Validate validates the ModelMonitor spec returning an Invalid error with all the invalid fields
+func (mm *ModelMonitor) Validate() error {
+	if mm == nil {
+		return fmt.Errorf(UnableToValidateModelMonitorError)
+	}
+
+	specPath := field.NewPath("spec")
+
+	var allErrs field.ErrorList
+	schemas := mm.Spec.Model.EffectiveSchemas()
+	allErrs = append(allErrs, validateModel(&mm.Spec.Model, specPath.Child("model"))...)
+	allErrs = append(allErrs, validateModelSchemas(schemas, specPath.Child("model", "schemas"))...)
+	allErrs = append(allErrs, validateMonitoring(&mm.Spec.Monitoring, &schemas.Instance, specPath.Child("monitoring"))...)
+	allErrs = append(allErrs, validateStorage(&mm.Spec.Storage, specPath.Child("storage"))...)
+	if mm.Spec.Monitoring.Alerting != nil {
+		allErrs = append(allErrs, validateAlerting(&mm.Spec, &schemas.Instance, specPath.Child("monitoring", "alerting"))...)
+	}
+	allErrs = append(allErrs, validateJob(&mm.Spec.Job, specPath.Child("job"))...)
+
+	if rebaseline, ok := mm.Annotations[constants.RebaselineAnnotation]; ok {
+		if _, err := ParseRebaselineTime(rebaseline); err != nil {
+			path := field.NewPath("metadata", "annotations").Key(constants.RebaselineAnnotation)
+			allErrs = append(allErrs, field.Invalid(path, rebaseline, InvalidRebaselineError))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "ModelMonitor"}, mm.Name, allErrs)
+}
+
+func validateModel(model *ModelSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	tensorsPath := path.Child("tensors")
+	switch {
+	case !model.IsV2() && model.Tensors != nil:
+		allErrs = append(allErrs, field.Forbidden(tensorsPath, TensorsOfV1ModelError))
+	case model.IsV2() && model.Tensors == nil:
+		allErrs = append(allErrs, field.Required(tensorsPath, MissingTensorsError))
+	case model.IsV2():
+		allErrs = append(allErrs, validateTensors(model.Tensors.Inputs, tensorsPath.Child("inputs"))...)
+		allErrs = append(allErrs, validateTensors(model.Tensors.Outputs, tensorsPath.Child("outputs"))...)
+	}
+
+	// Schemas of v1 protocol models are not derived, the payloads and instances are parsed with them
+	if !model.IsV2() {
+		schemasPath := path.Child("schemas")
+		for _, schema := range []struct {
+			name   string
+			schema *Schema
+		}{
+			{"request", &model.Schemas.Request},
+			{"response", &model.Schemas.Response},
+			{"instance", &model.Schemas.Instance},
+		} {
+			if len(schema.schema.Fields) == 0 {
+				allErrs = append(allErrs, field.Required(schemasPath.Child(schema.name, "fields"), MissingV1SchemaError))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateTensors(tensors []TensorSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(tensors) == 0 {
+		return append(allErrs, field.Required(path, ""))
+	}
+	names := map[string]bool{}
+	for i, tensor := range tensors {
+		tensorPath := path.Index(i)
+		if tensor.Name == "" {
+			allErrs = append(allErrs, field.Required(tensorPath.Child("name"), MissingTensorNameError))
+		} else if names[tensor.Name] {
+			allErrs = append(allErrs, field.Duplicate(tensorPath.Child("name"), tensor.Name))
+		}
+		names[tensor.Name] = true
+
+		if tensor.Datatype.SparkType() == "" {
+			allErrs = append(allErrs, field.NotSupported(tensorPath.Child("datatype"), tensor.Datatype, tensorDatatypes()))
+		}
+		for j, dimension := range tensor.Shape {
+			if dimension == 0 || dimension < -1 {
+				allErrs = append(allErrs, field.Invalid(tensorPath.Child("shape").Index(j), dimension, InvalidTensorDimensionError))
+			}
+		}
+		if len(tensor.Features) > 0 {
+			if len(tensor.Shape) != 2 {
+				allErrs = append(allErrs, field.Invalid(tensorPath.Child("features"), tensor.Features, FeaturesOfNon2DTensorError))
+			} else if !tensor.hasFeatures() {
+				allErrs = append(allErrs, field.Invalid(tensorPath.Child("features"), tensor.Features, fmt.Sprintf(FeaturesShapeMismatchError, tensor.Shape[1])))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateModelSchemas(schemas *ModelSchemasSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateSchema(&schemas.Request, path.Child("request"))...)
+	allErrs = append(allErrs, validateSchema(&schemas.Response, path.Child("response"))...)
+	allErrs = append(allErrs, validateSchema(&schemas.Instance, path.Child("instance"))...)
+	allErrs = append(allErrs, validateSchema(&schemas.Prediction, path.Child("prediction"))...)
+	return allErrs
+}
+
+func validateSchema(schema *Schema, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := map[string]bool{}
+	for i, schemaField := range schema.Fields {
+		fieldPath := path.Child("fields").Index(i)
+		if schemaField.Name == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("name"), MissingFieldNameError))
+		} else if names[schemaField.Name] {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Child("name"), schemaField.Name))
+		}
+		names[schemaField.Name] = true
+
+		if err := ValidateSparkType(schemaField.Type); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("type"), schemaField.Type, fmt.Sprintf(InvalidFieldTypeError, err)))
+		}
+		if len(schemaField.Values) > 0 && schemaField.Kind != CategoricalFeatureKind {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("values"), schemaField.Values, ValuesOfNonCategoricalFeatureError))
+		}
+	}
+	return allErrs
+}
+
+// validateFeatureReferences checks that the referenced features are fields of the instance schema
+func validateFeatureReferences(features []string, instance *Schema, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, feature := range features {
+		if instance.Field(feature) == nil {
+			allErrs = append(allErrs, field.NotFound(path.Index(i), feature))
+		}
+	}
+	return allErrs
+}
+
+func validateMonitoring(monitoring *MonitoringSpec, instance *Schema, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateTrigger(&monitoring.Trigger, path.Child("trigger"))...)
+	allErrs = append(allErrs, validateStats(&monitoring.Stats, path.Child("stats"))...)
+	allErrs = append(allErrs, validateFeatureReferences(monitoring.Stats.Features, instance, path.Child("stats", "features"))...)
+	if monitoring.Outliers != nil {
+		allErrs = append(allErrs, validateOutliers(monitoring.Outliers, &monitoring.Stats, path.Child("outliers"))...)
+	}
+	if monitoring.Drift != nil {
+		allErrs = append(allErrs, validateDrift(monitoring.Drift, path.Child("drift"))...)
+		allErrs = append(allErrs, validateFeatureReferences(monitoring.Drift.Features, instance, path.Child("drift", "features"))...)
+	}
+	if monitoring.Baseline != nil {
+		allErrs = append(allErrs, validateBaselines(monitoring, instance, path.Child("baseline"))...)
+	}
+	if perRevision := monitoring.PerRevision; perRevision != nil && perRevision.CompareCanary != nil && *perRevision.CompareCanary && monitoring.Drift == nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("perRevision", "compareCanary"), true, CompareCanaryWithoutDriftError))
+	}
+	return allErrs
+}
+
+func validateBaselines(monitoring *MonitoringSpec, instance *Schema, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, baseline := range monitoring.Baselines(instance) {
+		switch {
+		case baseline.Inline != "" && baseline.From != nil:
+			allErrs = append(allErrs, field.Invalid(path.Child(baseline.Name), baseline.Inline, fmt.Sprintf(InlineAndReferencedBaselineError, baseline.Name, baseline.Name)))
+		case baseline.Inline != "":
+			// Referenced baselines are validated by the operator
+			if err := ValidateBaseline(baseline.Inline, instance, baseline.Features); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child(baseline.Name), "<baseline>", err.Error()))
+			}
+		case baseline.From != nil:
+			allErrs = append(allErrs, validateBaselineSource(baseline.From, path.Child(baseline.Name+"From"))...)
+		}
+	}
+	if monitoring.Baseline.Dataset != nil && monitoring.Baseline.Traffic != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("traffic"), "", DatasetAndTrafficBaselineError))
+	}
+	if traffic := monitoring.Baseline.Traffic; traffic != nil {
+		allErrs = append(allErrs, validateTrafficBaseline(traffic, path.Child("traffic"))...)
+	}
+	if dataset := monitoring.Baseline.Dataset; dataset != nil {
+		if dataset.URI == "" {
+			allErrs = append(allErrs, field.Required(path.Child("dataset", "uri"), MissingDatasetURIError))
+		} else if err := validateBaselineURI(dataset.URI); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("dataset", "uri"), dataset.URI, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateTrafficBaseline(traffic *TrafficBaselineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	timeRange := traffic.From != nil || traffic.To != nil
+	switch {
+	case (traffic.Windows > 0) == timeRange, timeRange && (traffic.From == nil || traffic.To == nil):
+		allErrs = append(allErrs, field.Invalid(path, "", InvalidTrafficBaselineError))
+	case timeRange && !traffic.To.After(traffic.From.Time):
+		allErrs = append(allErrs, field.Invalid(path.Child("to"), traffic.To.String(), InvalidTrafficTimeRangeError))
+	}
+	return allErrs
+}
+
+func validateBaselineSource(source *BaselineSource, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	count := 0
+	if source.ConfigMapKeyRef != nil {
+		count++
+		if source.ConfigMapKeyRef.Name == "" || source.ConfigMapKeyRef.Key == "" {
+			allErrs = append(allErrs, field.Required(path.Child("configMapKeyRef"), MissingConfigMapKeySelectorError))
+		}
+	}
+	if source.SecretKeyRef != nil {
+		count++
+		allErrs = append(allErrs, validateSecretKeySelector(source.SecretKeyRef, path.Child("secretKeyRef"))...)
+	}
+	if source.URI != "" {
+		count++
+		if err := validateBaselineURI(source.URI); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("uri"), source.URI, err.Error()))
+		}
+	}
+	if count != 1 {
+		allErrs = append(allErrs, field.Invalid(path, "", InvalidBaselineSourceError))
+	}
+	return allErrs
+}
+
+func validateAlerting(spec *ModelMonitorSpec, instance *Schema, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	alerting := spec.Monitoring.Alerting
+
+	receivers := map[string]bool{}
+	for i, receiver := range alerting.Receivers {
+		receiverPath := path.Child("receivers").Index(i)
+		switch {
+		case receiver.Name == "":
+			allErrs = append(allErrs, field.Required(receiverPath.Child("name"), MissingAlertReceiverNameError))
+		case receivers[receiver.Name]:
+			allErrs = append(allErrs, field.Duplicate(receiverPath.Child("name"), receiver.Name))
+		}
+		receivers[receiver.Name] = true
+
+		if len(receiver.Types()) != 1 {
+			allErrs = append(allErrs, field.Invalid(receiverPath, receiver.Types(), InvalidAlertReceiverTypeError))
+			continue
+		}
+		switch {
+		case receiver.Alertmanager != nil:
+			allErrs = append(allErrs, validateHTTPURL(receiver.Alertmanager.URL, receiverPath.Child("alertmanager", "url"))...)
+		case receiver.Slack != nil:
+			allErrs = append(allErrs, validateSecretKeySelector(&receiver.Slack.URL, receiverPath.Child("slack", "url"))...)
+		}
+	}
+
+	rules := map[string]bool{}
+	for i, rule := range alerting.Rules {
+		rulePath := path.Child("rules").Index(i)
+		switch {
+		case rule.Name == "":
+			allErrs = append(allErrs, field.Required(rulePath.Child("name"), MissingAlertRuleNameError))
+		case rules[rule.Name]:
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		rules[rule.Name] = true
+
+		if spec.Storage.Analysis.AlertSourceKafka(rule.Source) == nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("source"), rule.Source, fmt.Sprintf(AlertSourceNotKafkaError, rule.Source)))
+		}
+		if rule.Detector != "" && !alertDetectorEnabled(&spec.Monitoring, rule.Source, rule.Detector) {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("detector"), rule.Detector, fmt.Sprintf(AlertDetectorNotEnabledError, rule.Source)))
+		}
+		if _, err := strconv.ParseFloat(rule.Threshold, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("threshold"), rule.Threshold, NonNumericThresholdError))
+		}
+		allErrs = append(allErrs, validateFeatureReferences(rule.Features, instance, rulePath.Child("features"))...)
+		for j, receiver := range rule.Receivers {
+			if !receivers[receiver] {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("receivers").Index(j), receiver, UnknownAlertReceiverError))
+			}
+		}
+	}
+	return allErrs
+}
+
+// alertDetectorEnabled returns whether the drift detector or outlier stat of an alerting rule is enabled
+func alertDetectorEnabled(monitoring *MonitoringSpec, source AlertSource, detector string) bool {
+	switch source {
+	case DriftAlertSource:
+		return monitoring.Drift != nil && monitoring.Drift.detectors()[detector]
+	case OutliersAlertSource:
+		if monitoring.Outliers == nil {
+			return false
+		}
+		for _, stat := range monitoring.Outliers.Descriptive {
+			if stat == detector {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateTrigger(trigger *TriggerSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	triggers := 0
+	for _, set := range []bool{trigger.Window != nil, trigger.Count != nil, trigger.Schedule != nil} {
+		if set {
+			triggers++
+		}
+	}
+	if triggers != 1 {
+		return append(allErrs, field.Invalid(path, trigger, InvalidTriggerError))
+	}
+	if trigger.Window != nil {
+		allErrs = append(allErrs, validateWindow(trigger.Window, path.Child("window"))...)
+	}
+	if count := trigger.Count; count != nil {
+		if count.Size <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("count", "size"), count.Size, NonPositiveWindowError))
+		}
+		if count.Slide < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("count", "slide"), count.Slide, NonPositiveWindowError))
+		} else if count.Slide > count.Size {
+			allErrs = append(allErrs, field.Invalid(path.Child("count", "slide"), count.Slide, SlideGreaterThanSizeError))
+		}
+	}
+	if schedule := trigger.Schedule; schedule != nil {
+		if !isValidCron(schedule.Cron) {
+			allErrs = append(allErrs, field.Invalid(path.Child("schedule", "cron"), schedule.Cron, InvalidCronError))
+		}
+		if schedule.Lookback.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("schedule", "lookback"), schedule.Lookback.Duration.String(), NonPositiveWindowError))
+		}
+	}
+	if trigger.MinSamples < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minSamples"), trigger.MinSamples, NegativeWatermarkDelayError))
+	}
+	return allErrs
+}
+
+// isValidCron checks the syntax of the standard cron expressions and descriptors supported by the Spark operator
+func isValidCron(cron string) bool {
+	if strings.HasPrefix(cron, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(cron, "@every ")))
+		return err == nil && interval > 0
+	}
+	switch cron {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return true
+	}
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return false
+	}
+	for _, cronField := range fields {
+		if !cronFieldRegexp.MatchString(cronField) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateWindow(window *WindowSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if window.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("duration"), window.Duration.Duration.String(), NonPositiveWindowError))
+	}
+	if slide := window.Slide; slide != nil {
+		if slide.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("slide"), slide.Duration.String(), NonPositiveWindowError))
+		} else if slide.Duration > window.Duration.Duration {
+			allErrs = append(allErrs, field.Invalid(path.Child("slide"), slide.Duration.String(), SlideGreaterThanDurationError))
+		}
+	}
+	if window.WatermarkDelay.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("watermarkDelay"), window.WatermarkDelay.Duration.String(), NegativeWatermarkDelayError))
+	}
+	return allErrs
+}
+
+func validateStats(stats *StatSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if stats.Perc != nil {
+		percPath := path.Child("perc", "percentiles")
+		for i, percentile := range stats.Perc.Percentiles {
+			value, err := strconv.ParseFloat(percentile, 64)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(percPath.Index(i), percentile, NonNumericPercentileError))
+			} else if value < 0 || value > 100 {
+				allErrs = append(allErrs, field.Invalid(percPath.Index(i), percentile, PercentileOutOfBoundsError))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateOutliers(outliers *OutlierSpec, stats *StatSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	enabled := stats.enabledStats()
+	for i, stat := range outliers.Descriptive {
+		if !enabled[stat] {
+			allErrs = append(allErrs, field.Invalid(path.Child("descriptive").Index(i), stat, OutlierStatNotEnabledError))
+		}
+	}
+	return allErrs
+}
+
+func validateDrift(drift *DriftSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	detectors := map[string]*ThresholdBasedDriftSpec{
+		"wasserstein":     drift.Wasserstein,
+		"kullbackLeibler": drift.KullbackLeibler,
+		"jensenShannon":   drift.JensenShannon,
+	}
+	for _, name := range []string{"wasserstein", "kullbackLeibler", "jensenShannon"} {
+		detector := detectors[name]
+		if detector == nil {
+			continue
+		}
+		if _, err := strconv.ParseFloat(detector.Threshold, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child(name, "threshold"), detector.Threshold, NonNumericThresholdError))
+		}
+	}
+	return allErrs
+}
+
+func validateStorage(storage *StorageSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Inference topic name is defaulted by the mutating webhook
+	allErrs = append(allErrs, validateKafkaSecurity(&storage.Inference.Kafka, path.Child("inference", "kafka"))...)
+	analysisPath := path.Child("analysis")
+	for _, sink := range storage.Analysis.Sinks() {
+		allErrs = append(allErrs, validateSink(sink.SinkSpec, analysisPath.Child(sink.Name))...)
+	}
+	return allErrs
+}
+
+func validateSink(sink *SinkSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if types := sink.Types(); len(types) != 1 {
+		allErrs = append(allErrs, field.Invalid(path, strings.Join(types, ", "), InvalidSinkTypeError))
+		return allErrs
+	}
+
+	switch {
+	case sink.Kafka != nil:
+		if sink.Kafka.Topic.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("kafka", "topic", "name"), MissingTopicNameError))
+		}
+		allErrs = append(allErrs, validateKafkaSecurity(sink.Kafka, path.Child("kafka"))...)
+	case sink.S3 != nil:
+		allErrs = append(allErrs, validateS3Sink(sink.S3, path.Child("s3"))...)
+	case sink.Pushgateway != nil:
+		allErrs = append(allErrs, validateHTTPURL(sink.Pushgateway.URL, path.Child("pushgateway", "url"))...)
+	case sink.JDBC != nil:
+		allErrs = append(allErrs, validateJDBCSink(sink.JDBC, path.Child("jdbc"))...)
+	case sink.Webhook != nil:
+		allErrs = append(allErrs, validateHTTPURL(sink.Webhook.URL, path.Child("webhook", "url"))...)
+	}
+	return allErrs
+}
+
+func validateS3Sink(s3 *S3SinkSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s3.Bucket == "" {
+		allErrs = append(allErrs, field.Required(path.Child("bucket"), MissingBucketError))
+	}
+	if s3.Endpoint != "" {
+		allErrs = append(allErrs, validateHTTPURL(s3.Endpoint, path.Child("endpoint"))...)
+	}
+	allErrs = append(allErrs, validateCredentials(s3.AccessKeyID, s3.SecretAccessKey, path, "accessKeyId", "secretAccessKey")...)
+	return allErrs
+}
+
+func validateJDBCSink(jdbc *JDBCSinkSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !strings.HasPrefix(jdbc.URL, "jdbc:") {
+		allErrs = append(allErrs, field.Invalid(path.Child("url"), jdbc.URL, InvalidJDBCURLError))
+	}
+	if jdbc.Table == "" {
+		allErrs = append(allErrs, field.Required(path.Child("table"), MissingTableError))
+	}
+	allErrs = append(allErrs, validateCredentials(jdbc.Username, jdbc.Password, path, "username", "password")...)
+	return allErrs
+}
+
+// validateCredentials validates a pair of secret key refs that must be set together
+func validateCredentials(first, second *corev1.SecretKeySelector, path *field.Path, firstName, secondName string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if (first == nil) != (second == nil) {
+		allErrs = append(allErrs, field.Invalid(path, "", fmt.Sprintf(IncompleteCredentialsError, firstName, secondName)))
+	}
+	if first != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(first, path.Child(firstName))...)
+	}
+	if second != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(second, path.Child(secondName))...)
+	}
+	return allErrs
+}
+
+func validateHTTPURL(value string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(path, value, InvalidHTTPURLError))
+	}
+	return allErrs
+}
+
+func validateKafkaSecurity(kafka *KafkaSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if kafka.TLS != nil {
+		tlsPath := path.Child("tls")
+		if kafka.TLS.CACert != nil {
+			allErrs = append(allErrs, validateSecretKeySelector(kafka.TLS.CACert, tlsPath.Child("caCert"))...)
+		}
+		allErrs = append(allErrs, validateCredentials(kafka.TLS.Cert, kafka.TLS.Key, tlsPath, "cert", "key")...)
+	}
+	if kafka.SASL != nil {
+		saslPath := path.Child("sasl")
+		allErrs = append(allErrs, validateSecretKeySelector(&kafka.SASL.Username, saslPath.Child("username"))...)
+		allErrs = append(allErrs, validateSecretKeySelector(&kafka.SASL.Password, saslPath.Child("password"))...)
+	}
+	return allErrs
+}
+
+func validateSecretKeySelector(selector *corev1.SecretKeySelector, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if selector.Name == "" || selector.Key == "" {
+		allErrs = append(allErrs, field.Required(path, MissingSecretKeySelectorError))
+	}
+	return allErrs
+}
+
+func validateJob(job *JobSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateResources(&job.Driver.ResourcesSpec, path.Child("driver"))...)
+	allErrs = append(allErrs, validateResources(&job.Executor.ResourcesSpec, path.Child("executor"))...)
+	allErrs = append(allErrs, validateJobVolumes(job, path)...)
+	if policy := job.RestartPolicy; policy != nil {
+		restartPath := path.Child("restartPolicy")
+		if policy.Retries != nil && *policy.Retries < 0 {
+			allErrs = append(allErrs, field.Invalid(restartPath.Child("retries"), *policy.Retries, NegativeValueError))
+		}
+		if policy.Backoff.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(restartPath.Child("backoff"), policy.Backoff.Duration.String(), NegativeValueError))
+		}
+		if policy.MaxBackoff.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(restartPath.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), NegativeValueError))
+		} else if policy.MaxBackoff.Duration > 0 && policy.MaxBackoff.Duration < policy.Backoff.Duration {
+			allErrs = append(allErrs, field.Invalid(restartPath.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), MaxBackoffLessThanBackoffError))
+		}
+	}
+	return allErrs
+}
+
+// validateJobVolumes checks that the volume mounts and the local checkpoint location refer to the job volumes, not
+// clashing with the volumes of the Secrets and ConfigMaps mounted by the operator
+func validateJobVolumes(job *JobSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	volumes := map[string]bool{}
+	for i, volume := range job.Volumes {
+		volumePath := path.Child("volumes").Index(i).Child("name")
+		switch {
+		case volume.Name == "":
+			allErrs = append(allErrs, field.Required(volumePath, MissingVolumeNameError))
+		case volumes[volume.Name]:
+			allErrs = append(allErrs, field.Duplicate(volumePath, volume.Name))
+		case strings.HasPrefix(volume.Name, constants.SecretVolumePrefix) || strings.HasPrefix(volume.Name, constants.ConfigMapVolumePrefix):
+			allErrs = append(allErrs, field.Invalid(volumePath, volume.Name, ReservedVolumeNameError))
+		}
+		volumes[volume.Name] = true
+	}
+	for i, mount := range job.VolumeMounts {
+		if !volumes[mount.Name] {
+			allErrs = append(allErrs, field.NotFound(path.Child("volumeMounts").Index(i).Child("name"), mount.Name))
+		}
+	}
+
+	// Local checkpoints are lost on restarts unless written to a volume
+	if location := job.CheckpointLocation; strings.HasPrefix(location, "/") {
+		mounted := false
+		for _, mount := range job.VolumeMounts {
+			if location == mount.MountPath || strings.HasPrefix(location, strings.TrimSuffix(mount.MountPath, "/")+"/") {
+				mounted = true
+			}
+		}
+		if !mounted {
+			allErrs = append(allErrs, field.Invalid(path.Child("checkpointLocation"), location, CheckpointNotMountedError))
+		}
+	}
+	return allErrs
+}
+
+func validateResources(resources *ResourcesSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if resources.Memory != "" && !sparkMemoryRegex.MatchString(resources.Memory) {
+		allErrs = append(allErrs, field.Invalid(path.Child("memory"), resources.Memory, UnsupportedSparkMemoryError))
+	}
+	return allErrs
+}
+
+// enabledStats returns the names of the stats enabled in the spec
+func (s *StatSpec) enabledStats() map[string]bool {
+	return map[string]bool{
+		"max":     s.Max != nil,
+		"min":     s.Min != nil,
+		"count":   s.Count != nil,
+		"sum":     s.Sum != nil,
+		"pow2Sum": s.Pow2Sum != nil,
+		"distr":   s.Distr != nil,
+		"avg":     s.Avg != nil,
+		"mean":    s.Mean != nil,
+		"stddev":  s.Stddev != nil,
+		"perc":    s.Perc != nil,
+		"cov":     s.Cov != nil,
+		"corr":    s.Corr != nil,
+	}
+}
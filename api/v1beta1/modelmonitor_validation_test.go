@@ -0,0 +1,281 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func newTestModelMonitor() *ModelMonitor {
+	schema := Schema{Fields: []SchemaField{{Name: "sepal_length", Type: "double"}}}
+	mm := &ModelMonitor{}
+	mm.Name = "iris"
+	mm.Namespace = "default"
+	mm.Spec.Model = ModelSpec{Name: "iris", Schemas: ModelSchemasSpec{Request: schema, Response: schema, Instance: schema, Prediction: schema}}
+	mm.Spec.Monitoring.Trigger.Window = &WindowSpec{Duration: Duration{10 * time.Second}}
+	mm.Spec.Storage.Inference.Kafka = KafkaSpec{Brokers: "broker:9092"}
+	mm.Spec.Storage.Analysis.Stats.Kafka = &KafkaSpec{Brokers: "broker:9092", Topic: KafkaTopicSpec{Name: "stats"}}
+	return mm
+}
+
+// invalidFields returns the fields of the causes of an Invalid error
+func invalidFields(g *gomega.GomegaWithT, err error) []string {
+	g.Expect(apierrors.IsInvalid(err)).To(gomega.BeTrue())
+	var fields []string
+	for _, cause := range err.(*apierrors.StatusError).ErrStatus.Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+	return fields
+}
+
+func TestModelMonitorValidate(t *testing.T) {
+	compareCanary := true
+	tests := []struct {
+		name   string
+		modify func(mm *ModelMonitor)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(mm *ModelMonitor) {},
+		},
+		{
+			name: "missing v1 schemas",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Model.Schemas = ModelSchemasSpec{}
+			},
+			fields: []string{"spec.model.schemas.request.fields", "spec.model.schemas.response.fields", "spec.model.schemas.instance.fields"},
+		},
+		{
+			name: "invalid schema field type",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Model.Schemas.Instance = Schema{Fields: []SchemaField{{Name: "sepal_length", Type: "number"}}}
+			},
+			fields: []string{"spec.model.schemas.instance.fields[0].type"},
+		},
+		{
+			name: "v2 model without tensors",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Model.Protocol = V2InferenceProtocol
+			},
+			fields: []string{"spec.model.tensors"},
+		},
+		{
+			name: "unknown feature",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Stats.Features = []string{"petal_width"}
+			},
+			fields: []string{"spec.monitoring.stats.features[0]"},
+		},
+		{
+			name: "non-positive window",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Trigger.Window = &WindowSpec{}
+			},
+			fields: []string{"spec.monitoring.trigger.window.duration"},
+		},
+		{
+			name: "missing trigger",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Trigger = TriggerSpec{}
+			},
+			fields: []string{"spec.monitoring.trigger"},
+		},
+		{
+			name: "several triggers",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Trigger.Count = &CountWindowSpec{Size: 100}
+			},
+			fields: []string{"spec.monitoring.trigger"},
+		},
+		{
+			name: "slide greater than the window",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Trigger.Window.Slide = &Duration{time.Minute}
+			},
+			fields: []string{"spec.monitoring.trigger.window.slide"},
+		},
+		{
+			name: "count slide greater than the window",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Trigger = TriggerSpec{Count: &CountWindowSpec{Size: 100, Slide: 200}}
+			},
+			fields: []string{"spec.monitoring.trigger.count.slide"},
+		},
+		{
+			name: "invalid cron",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Trigger = TriggerSpec{Schedule: &ScheduleSpec{Cron: "every hour", Lookback: Duration{time.Hour}}}
+			},
+			fields: []string{"spec.monitoring.trigger.schedule.cron"},
+		},
+		{
+			name: "percentile out of bounds",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Stats.Perc = &PercSpec{Percentiles: []string{"25", "101"}}
+			},
+			fields: []string{"spec.monitoring.stats.perc.percentiles[1]"},
+		},
+		{
+			name: "outlier stat not enabled",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Outliers = &OutlierSpec{Descriptive: []string{"max"}}
+			},
+			fields: []string{"spec.monitoring.outliers.descriptive[0]"},
+		},
+		{
+			name: "non-numeric drift threshold",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Drift = &DriftSpec{Wasserstein: &ThresholdBasedDriftSpec{Threshold: "high"}}
+			},
+			fields: []string{"spec.monitoring.drift.wasserstein.threshold"},
+		},
+		{
+			name: "canary compared without drift",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.PerRevision = &PerRevisionSpec{CompareCanary: &compareCanary}
+			},
+			fields: []string{"spec.monitoring.perRevision.compareCanary"},
+		},
+		{
+			name: "baseline with several sources",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Baseline = &BaselineSpec{DescriptiveFrom: &BaselineSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "baseline"}, Key: "descriptive"},
+					URI:             "s3a://bucket/baseline.json",
+				}}
+			},
+			fields: []string{"spec.monitoring.baseline.descriptiveFrom"},
+		},
+		{
+			name: "baseline dataset without uri",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Baseline = &BaselineSpec{Dataset: &BaselineDatasetSpec{}}
+			},
+			fields: []string{"spec.monitoring.baseline.dataset.uri"},
+		},
+		{
+			name: "alerting on results not written to kafka",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Monitoring.Drift = &DriftSpec{Wasserstein: &ThresholdBasedDriftSpec{Threshold: "0.5"}}
+				mm.Spec.Monitoring.Alerting = &AlertingSpec{
+					Rules:     []AlertRuleSpec{{Name: "drift", Source: DriftAlertSource, Threshold: "0.5"}},
+					Receivers: []AlertReceiverSpec{{Name: "events", Events: &EventsReceiverSpec{}}},
+				}
+			},
+			fields: []string{"spec.monitoring.alerting.rules[0].source"},
+		},
+		{
+			name: "sink without type",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Storage.Analysis.Stats = SinkSpec{}
+			},
+			fields: []string{"spec.storage.analysis.stats"},
+		},
+		{
+			name: "webhook sink without http url",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Storage.Analysis.Outliers = &SinkSpec{Webhook: &WebhookSinkSpec{URL: "ftp://alerts"}}
+			},
+			fields: []string{"spec.storage.analysis.outliers.webhook.url"},
+		},
+		{
+			name: "kafka sink without topic",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Storage.Analysis.Drift = &SinkSpec{Kafka: &KafkaSpec{Brokers: "broker:9092"}}
+			},
+			fields: []string{"spec.storage.analysis.drift.kafka.topic.name"},
+		},
+		{
+			name: "invalid memory",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Job.Executor.Memory = "1 gigabyte"
+			},
+			fields: []string{"spec.job.executor.memory"},
+		},
+		{
+			name: "max backoff less than the backoff",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Job.RestartPolicy = &RestartPolicySpec{Backoff: Duration{time.Minute}, MaxBackoff: Duration{time.Second}}
+			},
+			fields: []string{"spec.job.restartPolicy.maxBackoff"},
+		},
+		{
+			name: "incomplete kafka credentials",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Storage.Inference.Kafka.SASL = &KafkaSASLSpec{Mechanism: PlainSASLMechanism}
+			},
+			fields: []string{"spec.storage.inference.kafka.sasl.username", "spec.storage.inference.kafka.sasl.password"},
+		},
+		{
+			name: "incomplete kafka client certificate",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Storage.Inference.Kafka.TLS = &KafkaTLSSpec{
+					Cert: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka"}, Key: "cert"},
+				}
+			},
+			fields: []string{"spec.storage.inference.kafka.tls"},
+		},
+		{
+			name: "reserved volume name",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Job.Volumes = []corev1.Volume{{Name: "secret-kafka"}}
+			},
+			fields: []string{"spec.job.volumes[0].name"},
+		},
+		{
+			name: "checkpoint not mounted",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Job.CheckpointLocation = "/checkpoints"
+			},
+			fields: []string{"spec.job.checkpointLocation"},
+		},
+		{
+			name: "checkpoint mounted",
+			modify: func(mm *ModelMonitor) {
+				mm.Spec.Job.Volumes = []corev1.Volume{{Name: "checkpoints"}}
+				mm.Spec.Job.VolumeMounts = []corev1.VolumeMount{{Name: "checkpoints", MountPath: "/checkpoints"}}
+				mm.Spec.Job.CheckpointLocation = "/checkpoints/iris"
+			},
+		},
+		{
+			name: "rebaseline time",
+			modify: func(mm *ModelMonitor) {
+				mm.Annotations = map[string]string{constants.RebaselineAnnotation: "2020-09-13T12:26:40Z"}
+			},
+		},
+		{
+			name: "invalid rebaseline time",
+			modify: func(mm *ModelMonitor) {
+				mm.Annotations = map[string]string{constants.RebaselineAnnotation: "now"}
+			},
+			fields: []string{"metadata.annotations[monitoring.hops.io/rebaseline]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			mm := newTestModelMonitor()
+			test.modify(mm)
+
+			err := mm.Validate()
+			if test.fields == nil {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(invalidFields(g, err)).To(gomega.Equal(test.fields))
+		})
+	}
+}
+
+func TestModelMonitorValidateNil(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var mm *ModelMonitor
+	g.Expect(mm.Validate()).To(gomega.MatchError(UnableToValidateModelMonitorError))
+}
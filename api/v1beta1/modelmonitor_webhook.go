@@ -0,0 +1,73 @@
+/*
+Copyright 2020 Javier de la Rúa Martínez.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var modelmonitorlog = logf.Log.WithName("modelmonitor-resource")
+
+// SetupWebhookWithManager registers the ModelMonitor webhooks in the manager
+func (mm *ModelMonitor) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(mm).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-monitoring-hops-io-v1beta1-modelmonitor,mutating=true,failurePolicy=fail,groups=monitoring.hops.io,resources=modelmonitors,verbs=create;update,versions=v1beta1,name=mmodelmonitor.monitoring.hops.io
+
+var _ webhook.Defaulter = &ModelMonitor{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (mm *ModelMonitor) Default() {
+	modelmonitorlog.Info("Defaulting ModelMonitor", "namespace", mm.Namespace, "name", mm.Name)
+	mm.ApplyDefaults()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-monitoring-hops-io-v1beta1-modelmonitor,mutating=false,failurePolicy=fail,groups=monitoring.hops.io,resources=modelmonitors,versions=v1beta1,name=vmodelmonitor.monitoring.hops.io
+
+var _ webhook.Validator = &ModelMonitor{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (mm *ModelMonitor) ValidateCreate() error {
+	return mm.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (mm *ModelMonitor) ValidateUpdate(old runtime.Object) error {
+	return mm.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (mm *ModelMonitor) ValidateDelete() error {
+	return nil
+}
+
+func (mm *ModelMonitor) validate() error {
+	modelmonitorlog.Info("Validating ModelMonitor", "namespace", mm.Namespace, "name", mm.Name)
+	if err := mm.Validate(); err != nil {
+		modelmonitorlog.Info("Failed to validate ModelMonitor", "namespace", mm.Namespace, "name", mm.Name, "error", err.Error())
+		return err
+	}
+	modelmonitorlog.Info("Successfully validated ModelMonitor", "namespace", mm.Namespace, "name", mm.Name)
+	return nil
+}
@@ -0,0 +1,57 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestModelMonitorDefault(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Monitoring.Stats.Stddev = &StddevSpec{}
+	mm.Spec.Storage.Inference.Kafka.SASL = &KafkaSASLSpec{}
+
+	mm.Default()
+	g.Expect(mm.Spec.Model.Protocol).To(gomega.Equal(V1InferenceProtocol))
+	g.Expect(mm.Spec.Model.OnVersionChange).To(gomega.Equal(NoneVersionChangePolicy))
+	g.Expect(mm.Spec.Monitoring.Stats.Stddev.Type).To(gomega.Equal(constants.MonitoringStatsDefaultType))
+	g.Expect(mm.Spec.Storage.Inference.Kafka.Topic.Name).To(gomega.Equal(constants.DefaultKafkaTopicName("iris")))
+	g.Expect(mm.Spec.Storage.Inference.Kafka.SASL.Mechanism).To(gomega.Equal(PlainSASLMechanism))
+	g.Expect(mm.Spec.Storage.DeletionPolicy).To(gomega.Equal(RetainDeletionPolicy))
+	g.Expect(mm.Spec.Job.Driver.Memory).To(gomega.Equal(constants.MonitoringJobDriverMemory))
+	g.Expect(mm.Spec.Job.RestartPolicy.Type).To(gomega.Equal(OnFailureRestartPolicy))
+	g.Expect(mm.Spec.Job.RestartPolicy.Backoff.Duration).To(gomega.Equal(constants.MonitoringJobRestartBackoff))
+	g.Expect(mm.Spec.Job.Executor.Instances).To(gomega.Equal(constants.MonitoringJobExecutorInstances))
+	g.Expect(mm.Spec.InferenceLogger.MinScale).To(gomega.Equal(constants.InferenceLoggerDefaultMinScale))
+
+	// Baselines computed from a dataset are read from the ConfigMap written by the Baseline job
+	mm.Spec.Monitoring.Baseline = &BaselineSpec{Dataset: &BaselineDatasetSpec{URI: "s3a://bucket/iris/train.parquet"}}
+	mm.Default()
+	g.Expect(mm.Spec.Monitoring.Baseline.Dataset.Format).To(gomega.Equal(ParquetDatasetFormat))
+	g.Expect(mm.Spec.Monitoring.Baseline.DescriptiveFrom.ConfigMapKeyRef.Name).To(gomega.Equal(constants.DefaultBaselineConfigMapName("iris")))
+
+	// Defaults are idempotent
+	defaulted := mm.DeepCopy()
+	mm.Default()
+	g.Expect(mm).To(gomega.Equal(defaulted))
+}
+
+func TestModelMonitorValidateCreateAndUpdate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Default()
+	g.Expect(mm.ValidateCreate()).To(gomega.Succeed())
+	g.Expect(mm.ValidateUpdate(mm.DeepCopy())).To(gomega.Succeed())
+
+	invalid := mm.DeepCopy()
+	invalid.Spec.Monitoring.Trigger = TriggerSpec{}
+	g.Expect(apierrors.IsInvalid(invalid.ValidateCreate())).To(gomega.BeTrue())
+	g.Expect(apierrors.IsInvalid(invalid.ValidateUpdate(mm))).To(gomega.BeTrue())
+
+	// Deletion is never rejected
+	g.Expect(invalid.ValidateDelete()).To(gomega.Succeed())
+}
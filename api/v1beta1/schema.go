@@ -0,0 +1,344 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FeatureKind values
+const (
+	CategoricalFeatureKind FeatureKind = "categorical"
+	NumericalFeatureKind   FeatureKind = "numerical"
+)
+
+// Spark field metadata keys used to pass the feature metadata to the job
+const (
+	featureKindMetadataKey   = "kind"
+	featureValuesMetadataKey = "values"
+)
+
+// Spark primitive type names, by name and DDL alias
+var sparkPrimitiveTypes = map[string]string{
+	"boolean":   "boolean",
+	"byte":      "byte",
+	"tinyint":   "byte",
+	"short":     "short",
+	"smallint":  "short",
+	"integer":   "integer",
+	"int":       "integer",
+	"long":      "long",
+	"bigint":    "long",
+	"float":     "float",
+	"double":    "double",
+	"string":    "string",
+	"binary":    "binary",
+	"date":      "date",
+	"timestamp": "timestamp",
+	"null":      "null",
+}
+
+// UnmarshalJSON decodes a Schema, accepting Spark StructType JSON strings too
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var sparkSchema string
+		if err := json.Unmarshal(data, &sparkSchema); err != nil {
+			return err
+		}
+		schema, err := ParseSparkSchema(sparkSchema)
+		if err != nil {
+			return err
+		}
+		*s = *schema
+		return nil
+	}
+
+	type plainSchema Schema
+	return json.Unmarshal(data, (*plainSchema)(s))
+}
+
+// IsNullable returns whether the field is nullable, true by default
+func (f *SchemaField) IsNullable() bool {
+	return f.Nullable == nil || *f.Nullable
+}
+
+// Field returns a field by name
+func (s *Schema) Field(name string) *SchemaField {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// SparkJSON converts the schema to the Spark StructType JSON expected by the Monitoring job
+func (s *Schema) SparkJSON() (string, error) {
+	fields := []interface{}{}
+	for _, field := range s.Fields {
+		dataType, err := parseSparkType(field.Type)
+		if err != nil {
+			return "", fmt.Errorf("Invalid type of field %s: %v", field.Name, err)
+		}
+		metadata := map[string]interface{}{}
+		if field.Kind != "" {
+			metadata[featureKindMetadataKey] = field.Kind
+		}
+		if len(field.Values) > 0 {
+			metadata[featureValuesMetadataKey] = field.Values
+		}
+		fields = append(fields, map[string]interface{}{
+			"name":     field.Name,
+			"type":     dataType,
+			"nullable": field.IsNullable(),
+			"metadata": metadata,
+		})
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"type": "struct", "fields": fields})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ParseSparkSchema converts a Spark StructType JSON to a Schema. An empty JSON object is an empty schema.
+func ParseSparkSchema(sparkSchema string) (*Schema, error) {
+	var structType struct {
+		Type   string `json:"type"`
+		Fields []struct {
+			Name     string                 `json:"name"`
+			Type     interface{}            `json:"type"`
+			Nullable *bool                  `json:"nullable"`
+			Metadata map[string]interface{} `json:"metadata"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(sparkSchema), &structType); err != nil {
+		return nil, fmt.Errorf("Invalid Spark schema: %v", err)
+	}
+	if structType.Type != "" && structType.Type != "struct" {
+		return nil, fmt.Errorf("Invalid Spark schema: type must be struct, found %s", structType.Type)
+	}
+
+	schema := &Schema{}
+	for _, sparkField := range structType.Fields {
+		dataType, err := formatSparkType(sparkField.Type)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid Spark schema: field %s: %v", sparkField.Name, err)
+		}
+		field := SchemaField{Name: sparkField.Name, Type: dataType}
+		if sparkField.Nullable != nil && !*sparkField.Nullable {
+			field.Nullable = sparkField.Nullable
+		}
+		if kind, ok := sparkField.Metadata[featureKindMetadataKey].(string); ok {
+			field.Kind = FeatureKind(kind)
+		}
+		if values, ok := sparkField.Metadata[featureValuesMetadataKey].([]interface{}); ok {
+			for _, value := range values {
+				field.Values = append(field.Values, fmt.Sprint(value))
+			}
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+	return schema, nil
+}
+
+// ValidateSparkType checks that a type is a valid Spark SQL data type
+func ValidateSparkType(dataType string) error {
+	_, err := parseSparkType(dataType)
+	return err
+}
+
+// parseSparkType converts a Spark SQL data type (e.g array<double>) to its Spark JSON representation
+func parseSparkType(dataType string) (interface{}, error) {
+	p := &sparkTypeParser{input: dataType}
+	result, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d of %q", p.input[p.pos:], p.pos, dataType)
+	}
+	return result, nil
+}
+
+// formatSparkType converts a Spark JSON data type to its Spark SQL representation
+func formatSparkType(dataType interface{}) (string, error) {
+	switch t := dataType.(type) {
+	case string:
+		if _, err := parseSparkType(t); err != nil {
+			return "", err
+		}
+		return t, nil
+	case map[string]interface{}:
+		switch t["type"] {
+		case "array":
+			element, err := formatSparkType(t["elementType"])
+			if err != nil {
+				return "", err
+			}
+			return "array<" + element + ">", nil
+		case "map":
+			key, err := formatSparkType(t["keyType"])
+			if err != nil {
+				return "", err
+			}
+			value, err := formatSparkType(t["valueType"])
+			if err != nil {
+				return "", err
+			}
+			return "map<" + key + "," + value + ">", nil
+		case "struct":
+			fields, _ := t["fields"].([]interface{})
+			var formatted []string
+			for _, f := range fields {
+				field, _ := f.(map[string]interface{})
+				name, _ := field["name"].(string)
+				fieldType, err := formatSparkType(field["type"])
+				if err != nil {
+					return "", err
+				}
+				formatted = append(formatted, name+":"+fieldType)
+			}
+			return "struct<" + strings.Join(formatted, ",") + ">", nil
+		}
+		return "", fmt.Errorf("unsupported type %v", t["type"])
+	}
+	return "", fmt.Errorf("unsupported type %v", dataType)
+}
+
+// sparkTypeParser parses Spark SQL data types. Nested arrays and maps are nullable, as in Spark defaults.
+type sparkTypeParser struct {
+	input string
+	pos   int
+}
+
+func (p *sparkTypeParser) parseType() (interface{}, error) {
+	name := strings.ToLower(p.parseIdentifier())
+	switch name {
+	case "array":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		element, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "elementType": element, "containsNull": true}, nil
+
+	case "map":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		key, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+		value, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "map", "keyType": key, "valueType": value, "valueContainsNull": true}, nil
+
+	case "struct":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		fields := []interface{}{}
+		for !p.peek('>') {
+			if len(fields) > 0 {
+				if err := p.expect(','); err != nil {
+					return nil, err
+				}
+			}
+			fieldName := p.parseIdentifier()
+			if fieldName == "" {
+				return nil, fmt.Errorf("missing struct field name at position %d of %q", p.pos, p.input)
+			}
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			fieldType, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, map[string]interface{}{
+				"name": fieldName, "type": fieldType, "nullable": true, "metadata": map[string]interface{}{},
+			})
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "struct", "fields": fields}, nil
+
+	case "decimal":
+		precision, scale := 10, 0
+		if p.peek('(') {
+			p.expect('(')
+			var err error
+			if precision, err = strconv.Atoi(p.parseIdentifier()); err != nil {
+				return nil, fmt.Errorf("invalid decimal precision in %q", p.input)
+			}
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+			if scale, err = strconv.Atoi(p.parseIdentifier()); err != nil {
+				return nil, fmt.Errorf("invalid decimal scale in %q", p.input)
+			}
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+		}
+		return fmt.Sprintf("decimal(%d,%d)", precision, scale), nil
+	}
+
+	if primitive, ok := sparkPrimitiveTypes[name]; ok {
+		return primitive, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing type at position %d of %q", p.pos, p.input)
+	}
+	return nil, fmt.Errorf("unknown type %q", name)
+}
+
+func (p *sparkTypeParser) parseIdentifier() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *sparkTypeParser) peek(c byte) bool {
+	p.skipSpaces()
+	return p.pos < len(p.input) && p.input[p.pos] == c
+}
+
+func (p *sparkTypeParser) expect(c byte) error {
+	if !p.peek(c) {
+		return fmt.Errorf("expected %q at position %d of %q", c, p.pos, p.input)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *sparkTypeParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
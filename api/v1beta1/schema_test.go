@@ -0,0 +1,60 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestSchemaSparkJSONRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	notNullable := false
+	schema := &Schema{Fields: []SchemaField{
+		{Name: "instances", Type: "array<array<double>>"},
+		{Name: "species", Type: "string", Nullable: &notNullable, Kind: CategoricalFeatureKind, Values: []string{"setosa", "virginica"}},
+		{Name: "meta", Type: "struct<id:bigint,tags:map<string,decimal(10,2)>>"},
+	}}
+
+	sparkSchema, err := schema.SparkJSON()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(sparkSchema).To(gomega.MatchJSON(`{"type": "struct", "fields": [
+		{"name": "instances", "nullable": true, "metadata": {}, "type": {"type": "array", "containsNull": true,
+			"elementType": {"type": "array", "containsNull": true, "elementType": "double"}}},
+		{"name": "species", "nullable": false, "type": "string", "metadata": {"kind": "categorical", "values": ["setosa", "virginica"]}},
+		{"name": "meta", "nullable": true, "metadata": {}, "type": {"type": "struct", "fields": [
+			{"name": "id", "type": "long", "nullable": true, "metadata": {}},
+			{"name": "tags", "nullable": true, "metadata": {}, "type": {"type": "map", "keyType": "string",
+				"valueType": "decimal(10,2)", "valueContainsNull": true}}]}}]}`))
+
+	parsed, err := ParseSparkSchema(sparkSchema)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(parsed.Fields[0].Type).To(gomega.Equal("array<array<double>>"))
+	g.Expect(parsed.Fields[1]).To(gomega.Equal(schema.Fields[1]))
+	g.Expect(parsed.Fields[2].Type).To(gomega.Equal("struct<id:long,tags:map<string,decimal(10,2)>>"))
+}
+
+func TestSchemaUnmarshalSparkJSONString(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var schemas ModelSchemasSpec
+	err := json.Unmarshal([]byte(`{
+		"instance": "{ \"type\": \"struct\", \"fields\": [ { \"name\": \"sepal_length\", \"type\": \"double\", \"nullable\": true, \"metadata\": {} } ] }",
+		"prediction": "{}",
+		"request": {"fields": [{"name": "instances", "type": "array<double>"}]}
+	}`), &schemas)
+
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(schemas.Instance.Fields).To(gomega.Equal([]SchemaField{{Name: "sepal_length", Type: "double"}}))
+	g.Expect(schemas.Prediction.Fields).To(gomega.BeEmpty())
+	g.Expect(schemas.Request.Fields[0].Type).To(gomega.Equal("array<double>"))
+}
+
+func TestValidateSparkType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, valid := range []string{"double", "INT", "array<string>", "map<string, array<long>>", "struct<a:int, b:string>", "decimal"} {
+		g.Expect(ValidateSparkType(valid)).To(gomega.Succeed(), valid)
+	}
+	for _, invalid := range []string{"", "float64", "array<double", "array<double>>", "map<string>", "struct<:int>"} {
+		g.Expect(ValidateSparkType(invalid)).NotTo(gomega.Succeed(), invalid)
+	}
+}
@@ -0,0 +1,167 @@
+package v1beta1
+
+import (
+	"sort"
+)
+
+// InferenceProtocol values
+const (
+	V1InferenceProtocol InferenceProtocol = "v1"
+	V2InferenceProtocol InferenceProtocol = "v2"
+)
+
+// TensorDatatype values
+const (
+	BoolTensorDatatype   TensorDatatype = "BOOL"
+	Uint8TensorDatatype  TensorDatatype = "UINT8"
+	Uint16TensorDatatype TensorDatatype = "UINT16"
+	Uint32TensorDatatype TensorDatatype = "UINT32"
+	Uint64TensorDatatype TensorDatatype = "UINT64"
+	Int8TensorDatatype   TensorDatatype = "INT8"
+	Int16TensorDatatype  TensorDatatype = "INT16"
+	Int32TensorDatatype  TensorDatatype = "INT32"
+	Int64TensorDatatype  TensorDatatype = "INT64"
+	Fp16TensorDatatype   TensorDatatype = "FP16"
+	Fp32TensorDatatype   TensorDatatype = "FP32"
+	Fp64TensorDatatype   TensorDatatype = "FP64"
+	BytesTensorDatatype  TensorDatatype = "BYTES"
+)
+
+// Spark types of the tensor datatypes. Unsigned integers are widened to fit their range.
+var tensorSparkTypes = map[TensorDatatype]string{
+	BoolTensorDatatype:   "boolean",
+	Uint8TensorDatatype:  "short",
+	Uint16TensorDatatype: "integer",
+	Uint32TensorDatatype: "long",
+	Uint64TensorDatatype: "decimal(20,0)",
+	Int8TensorDatatype:   "byte",
+	Int16TensorDatatype:  "short",
+	Int32TensorDatatype:  "integer",
+	Int64TensorDatatype:  "long",
+	Fp16TensorDatatype:   "float",
+	Fp32TensorDatatype:   "float",
+	Fp64TensorDatatype:   "double",
+	BytesTensorDatatype:  "string",
+}
+
+// v2 protocol payload types. Tensor data is kept as raw JSON values, since tensors of a payload have different
+// datatypes, and cast by the job using the instance and prediction schemas.
+const (
+	v2ParametersType     = "map<string,string>"
+	v2RequestInputType   = "struct<name:string,shape:array<long>,datatype:string,parameters:map<string,string>,data:array<string>>"
+	v2RequestOutputType  = "struct<name:string,parameters:map<string,string>>"
+	v2ResponseOutputType = v2RequestInputType
+)
+
+// tensorDatatypes returns the supported tensor datatypes, sorted
+func tensorDatatypes() []string {
+	var datatypes []string
+	for datatype := range tensorSparkTypes {
+		datatypes = append(datatypes, string(datatype))
+	}
+	sort.Strings(datatypes)
+	return datatypes
+}
+
+// SparkType returns the Spark SQL type of the tensor datatype, or an empty string if unknown
+func (d TensorDatatype) SparkType() string {
+	return tensorSparkTypes[d]
+}
+
+// IsV2 returns whether the model is served with the v2 protocol
+func (s *ModelSpec) IsV2() bool {
+	return s.Protocol == V2InferenceProtocol
+}
+
+// EffectiveSchemas returns the model schemas, deriving the ones left empty from the tensors of v2 protocol models.
+// Derived schemas are not persisted so they follow the tensor changes.
+func (s *ModelSpec) EffectiveSchemas() *ModelSchemasSpec {
+	schemas := s.Schemas.DeepCopy()
+	if !s.IsV2() || s.Tensors == nil {
+		return schemas
+	}
+
+	derived := s.Tensors.Schemas()
+	for _, pair := range []struct{ schema, derived *Schema }{
+		{&schemas.Request, &derived.Request},
+		{&schemas.Response, &derived.Response},
+		{&schemas.Instance, &derived.Instance},
+		{&schemas.Prediction, &derived.Prediction},
+	} {
+		if len(pair.schema.Fields) == 0 {
+			*pair.schema = *pair.derived
+		}
+	}
+	return schemas
+}
+
+// Schemas derives the model schemas from the tensors. The request and response schemas follow the v2 protocol
+// payloads, and the instance and prediction schemas have a field per tensor (or per feature of a tensor).
+func (t *ModelTensorsSpec) Schemas() *ModelSchemasSpec {
+	return &ModelSchemasSpec{
+		Request: Schema{Fields: []SchemaField{
+			{Name: "id", Type: "string"},
+			{Name: "parameters", Type: v2ParametersType},
+			{Name: "inputs", Type: "array<" + v2RequestInputType + ">"},
+			{Name: "outputs", Type: "array<" + v2RequestOutputType + ">"},
+		}},
+		Response: Schema{Fields: []SchemaField{
+			{Name: "model_name", Type: "string"},
+			{Name: "model_version", Type: "string"},
+			{Name: "id", Type: "string"},
+			{Name: "parameters", Type: v2ParametersType},
+			{Name: "outputs", Type: "array<" + v2ResponseOutputType + ">"},
+		}},
+		Instance:   *tensorsSchema(t.Inputs),
+		Prediction: *tensorsSchema(t.Outputs),
+	}
+}
+
+func tensorsSchema(tensors []TensorSpec) *Schema {
+	schema := &Schema{}
+	for _, tensor := range tensors {
+		schema.Fields = append(schema.Fields, tensor.fields()...)
+	}
+	return schema
+}
+
+// fields returns the schema fields of a single instance of the tensor, i.e dropping the batch dimension
+func (t *TensorSpec) fields() []SchemaField {
+	sparkType := t.Datatype.SparkType()
+	if t.hasFeatures() {
+		var fields []SchemaField
+		for _, feature := range t.Features {
+			fields = append(fields, tensorField(feature, t.Datatype, sparkType))
+		}
+		return fields
+	}
+
+	if len(t.Shape) <= 1 {
+		return []SchemaField{tensorField(t.Name, t.Datatype, sparkType)}
+	}
+	for range t.Shape[1:] {
+		sparkType = "array<" + sparkType + ">"
+	}
+	return []SchemaField{{Name: t.Name, Type: sparkType}}
+}
+
+// hasFeatures returns whether the features match the second dimension of a [batch, features] tensor
+func (t *TensorSpec) hasFeatures() bool {
+	if len(t.Features) == 0 || len(t.Shape) != 2 {
+		return false
+	}
+	return t.Shape[1] == -1 || t.Shape[1] == int64(len(t.Features))
+}
+
+func tensorField(name string, datatype TensorDatatype, sparkType string) SchemaField {
+	field := SchemaField{Name: name, Type: sparkType}
+	switch datatype {
+	case BoolTensorDatatype:
+		field.Kind = CategoricalFeatureKind
+	case BytesTensorDatatype:
+		// Either categorical or free text, left unset
+	default:
+		field.Kind = NumericalFeatureKind
+	}
+	return field
+}
@@ -0,0 +1,79 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func newTestV2ModelSpec() *ModelSpec {
+	return &ModelSpec{
+		Name:     "iris",
+		Protocol: V2InferenceProtocol,
+		Tensors: &ModelTensorsSpec{
+			Inputs: []TensorSpec{
+				{Name: "input-0", Datatype: Fp32TensorDatatype, Shape: []int64{-1, 2}, Features: []string{"sepal_length", "sepal_width"}},
+				{Name: "image", Datatype: Uint8TensorDatatype, Shape: []int64{-1, 28, 28}},
+				{Name: "country", Datatype: BytesTensorDatatype, Shape: []int64{-1}},
+			},
+			Outputs: []TensorSpec{
+				{Name: "output-0", Datatype: Int64TensorDatatype, Shape: []int64{-1}},
+			},
+		},
+	}
+}
+
+func TestModelSpecEffectiveSchemas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	model := newTestV2ModelSpec()
+	model.Schemas.Prediction = Schema{Fields: []SchemaField{{Name: "species", Type: "string", Kind: CategoricalFeatureKind}}}
+
+	schemas := model.EffectiveSchemas()
+	g.Expect(schemas.Instance.Fields).To(gomega.Equal([]SchemaField{
+		{Name: "sepal_length", Type: "float", Kind: NumericalFeatureKind},
+		{Name: "sepal_width", Type: "float", Kind: NumericalFeatureKind},
+		{Name: "image", Type: "array<array<short>>"},
+		{Name: "country", Type: "string"},
+	}))
+	g.Expect(schemas.Prediction).To(gomega.Equal(model.Schemas.Prediction))
+	for _, schema := range []Schema{schemas.Request, schemas.Response, schemas.Instance} {
+		g.Expect(validateSchema(&schema, field.NewPath("schema"))).To(gomega.BeEmpty())
+		_, err := schema.SparkJSON()
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	model.Protocol = V1InferenceProtocol
+	g.Expect(model.EffectiveSchemas()).To(gomega.Equal(&model.Schemas))
+}
+
+func TestValidateModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	path := field.NewPath("model")
+
+	model := newTestV2ModelSpec()
+	g.Expect(validateModel(model, path)).To(gomega.BeEmpty())
+
+	model.Tensors.Inputs[0].Features = []string{"sepal_length"}
+	model.Tensors.Inputs[1].Shape[1] = 0
+	model.Tensors.Inputs[2].Name = "image"
+	model.Tensors.Outputs[0].Datatype = "FLOAT"
+	errs := validateModel(model, path)
+	g.Expect(errs.ToAggregate().Errors()).To(gomega.HaveLen(4))
+	g.Expect(errs[0].Field).To(gomega.Equal("model.tensors.inputs[0].features"))
+
+	model.Tensors = nil
+	g.Expect(validateModel(model, path)).To(gomega.HaveLen(1))
+
+	model.Protocol = V1InferenceProtocol
+	errs = validateModel(model, path)
+	g.Expect(errs).To(gomega.HaveLen(3))
+	g.Expect(errs[0].Field).To(gomega.Equal("model.schemas.request.fields"))
+	g.Expect(errs[0].Detail).To(gomega.Equal(MissingV1SchemaError))
+
+	schema := Schema{Fields: []SchemaField{{Name: "sepal_length", Type: "double"}}}
+	model.Schemas = ModelSchemasSpec{Request: schema, Response: schema, Instance: schema}
+	g.Expect(validateModel(model, path)).To(gomega.BeEmpty())
+	model.Tensors = &ModelTensorsSpec{}
+	g.Expect(validateModel(model, path)).To(gomega.HaveLen(1))
+}
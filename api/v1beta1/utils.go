@@ -0,0 +1,236 @@
+package v1beta1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+// DeletionPolicy values
+const (
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+)
+
+// VersionChangePolicy values
+const (
+	// NoneVersionChangePolicy only records the serving revisions
+	NoneVersionChangePolicy VersionChangePolicy = "None"
+	// RestartVersionChangePolicy restarts the Monitoring job, so windows don't span two model versions
+	RestartVersionChangePolicy VersionChangePolicy = "Restart"
+	// RebaselineVersionChangePolicy restarts the Monitoring job, computing the baseline from the new model version
+	RebaselineVersionChangePolicy VersionChangePolicy = "Rebaseline"
+)
+
+// RestartPolicyType values
+const (
+	// NeverRestartPolicy leaves the job terminated
+	NeverRestartPolicy RestartPolicyType = "Never"
+	// OnFailureRestartPolicy restarts the job when it fails or cannot be submitted
+	OnFailureRestartPolicy RestartPolicyType = "OnFailure"
+	// AlwaysRestartPolicy also restarts the job when it completes
+	AlwaysRestartPolicy RestartPolicyType = "Always"
+)
+
+// InferenceService endpoints, as set in the endpoint CloudEvent extension by KFServing
+const (
+	DefaultEndpoint = "default"
+	CanaryEndpoint  = "canary"
+)
+
+// KafkaSASLMechanism values
+const (
+	PlainSASLMechanism       KafkaSASLMechanism = "PLAIN"
+	ScramSHA256SASLMechanism KafkaSASLMechanism = "SCRAM-SHA-256"
+	ScramSHA512SASLMechanism KafkaSASLMechanism = "SCRAM-SHA-512"
+)
+
+// AlertSource values
+const (
+	DriftAlertSource    AlertSource = "drift"
+	OutliersAlertSource AlertSource = "outliers"
+)
+
+// AlertSeverity values
+const (
+	InfoAlertSeverity     AlertSeverity = "info"
+	WarningAlertSeverity  AlertSeverity = "warning"
+	CriticalAlertSeverity AlertSeverity = "critical"
+)
+
+// Alert receiver types
+const (
+	AlertmanagerReceiverType = "alertmanager"
+	SlackReceiverType        = "slack"
+	EventsReceiverType       = "events"
+)
+
+// DatasetFormat values
+const (
+	ParquetDatasetFormat DatasetFormat = "parquet"
+	CSVDatasetFormat     DatasetFormat = "csv"
+	JSONDatasetFormat    DatasetFormat = "json"
+)
+
+// S3SinkFormat values
+const (
+	ParquetS3SinkFormat S3SinkFormat = "parquet"
+	JSONS3SinkFormat    S3SinkFormat = "json"
+)
+
+// Sink type names, matching the SinkSpec fields
+const (
+	KafkaSinkType       = "kafka"
+	S3SinkType          = "s3"
+	PushgatewaySinkType = "pushgateway"
+	JDBCSinkType        = "jdbc"
+	WebhookSinkType     = "webhook"
+)
+
+// JobModelRevision returns the InferenceService revision the Monitoring job is run for, or an empty string if version
+// changes are ignored. The last known revision is kept while the InferenceService has no ready revision.
+func (mm *ModelMonitor) JobModelRevision() string {
+	if mm.Spec.Model.OnVersionChange == "" || mm.Spec.Model.OnVersionChange == NoneVersionChangePolicy {
+		return ""
+	}
+	if revision := mm.Status.InferenceService.Revision; revision != "" {
+		return revision
+	}
+	return mm.Status.MonitoringJob.ModelRevision
+}
+
+// AnalysisSink defines an analysis sink along with its name
+// +kubebuilder:object:generate=false
+type AnalysisSink struct {
+	Name string
+	*SinkSpec
+}
+
+// Sinks returns the analysis sinks set
+func (s *AnalysisSpec) Sinks() []AnalysisSink {
+	sinks := []AnalysisSink{{Name: "stats", SinkSpec: &s.Stats}}
+	if s.Outliers != nil {
+		sinks = append(sinks, AnalysisSink{Name: "outliers", SinkSpec: s.Outliers})
+	}
+	if s.Drift != nil {
+		sinks = append(sinks, AnalysisSink{Name: "drift", SinkSpec: s.Drift})
+	}
+	return sinks
+}
+
+// KafkaSpecs returns the Kafka settings of the inference sink and the Kafka analysis sinks
+func (s *StorageSpec) KafkaSpecs() []*KafkaSpec {
+	specs := []*KafkaSpec{&s.Inference.Kafka}
+	for _, sink := range s.Analysis.Sinks() {
+		if sink.Kafka != nil {
+			specs = append(specs, sink.Kafka)
+		}
+	}
+	return specs
+}
+
+// SecretNames returns the names of the Secrets referenced by all the sinks, without duplicates
+func (s *StorageSpec) SecretNames() []string {
+	var selectors []*corev1.SecretKeySelector
+	for _, kafka := range s.KafkaSpecs() {
+		selectors = append(selectors, kafka.secretKeySelectors()...)
+	}
+	for _, sink := range s.Analysis.Sinks() {
+		if sink.S3 != nil {
+			selectors = append(selectors, sink.S3.AccessKeyID, sink.S3.SecretAccessKey)
+		}
+		if sink.JDBC != nil {
+			selectors = append(selectors, sink.JDBC.Username, sink.JDBC.Password)
+		}
+	}
+	return secretNames(selectors)
+}
+
+// Types returns the names of the sink kinds set. Valid sinks have exactly one.
+func (s *SinkSpec) Types() []string {
+	var types []string
+	if s.Kafka != nil {
+		types = append(types, KafkaSinkType)
+	}
+	if s.S3 != nil {
+		types = append(types, S3SinkType)
+	}
+	if s.Pushgateway != nil {
+		types = append(types, PushgatewaySinkType)
+	}
+	if s.JDBC != nil {
+		types = append(types, JDBCSinkType)
+	}
+	if s.Webhook != nil {
+		types = append(types, WebhookSinkType)
+	}
+	return types
+}
+
+// SecurityProtocol returns the Kafka security protocol (i.e PLAINTEXT, SSL, SASL_PLAINTEXT or SASL_SSL)
+func (k *KafkaSpec) SecurityProtocol() string {
+	switch {
+	case k.SASL != nil && k.TLS != nil:
+		return "SASL_SSL"
+	case k.SASL != nil:
+		return "SASL_PLAINTEXT"
+	case k.TLS != nil:
+		return "SSL"
+	}
+	return "PLAINTEXT"
+}
+
+// SecretNames returns the names of the Secrets referenced by the Kafka security settings, without duplicates
+func (k *KafkaSpec) SecretNames() []string {
+	return secretNames(k.secretKeySelectors())
+}
+
+func (k *KafkaSpec) secretKeySelectors() []*corev1.SecretKeySelector {
+	var selectors []*corev1.SecretKeySelector
+	if k.TLS != nil {
+		selectors = append(selectors, k.TLS.CACert, k.TLS.Cert, k.TLS.Key)
+	}
+	if k.SASL != nil {
+		selectors = append(selectors, &k.SASL.Username, &k.SASL.Password)
+	}
+	return selectors
+}
+
+func secretNames(selectors []*corev1.SecretKeySelector) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, selector := range selectors {
+		if selector == nil || selector.Name == "" || seen[selector.Name] {
+			continue
+		}
+		seen[selector.Name] = true
+		names = append(names, selector.Name)
+	}
+	return names
+}
+
+// RestartsOn returns whether the policy restarts a job terminated in a Spark Application state
+func (s *RestartPolicySpec) RestartsOn(state sparkv1beta2.ApplicationStateType) bool {
+	switch state {
+	case sparkv1beta2.FailedState, sparkv1beta2.FailedSubmissionState:
+		return s.Type == OnFailureRestartPolicy || s.Type == AlwaysRestartPolicy
+	case sparkv1beta2.CompletedState:
+		return s.Type == AlwaysRestartPolicy
+	}
+	return false
+}
+
+// Delay returns the backoff before restarting a job restarted a number of consecutive times, doubling the backoff on
+// each restart up to the maximum
+func (s *RestartPolicySpec) Delay(restarts int32) time.Duration {
+	delay := s.Backoff.Duration
+	for i := int32(0); i < restarts && delay < s.MaxBackoff.Duration; i++ {
+		delay *= 2
+	}
+	if delay > s.MaxBackoff.Duration {
+		delay = s.MaxBackoff.Duration
+	}
+	return delay
+}
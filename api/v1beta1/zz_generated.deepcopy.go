@@ -21,22 +21,152 @@ limitations under the License.
 package v1beta1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertReceiverSpec) DeepCopyInto(out *AlertReceiverSpec) {
+	*out = *in
+	if in.Alertmanager != nil {
+		in, out := &in.Alertmanager, &out.Alertmanager
+		*out = new(AlertmanagerReceiverSpec)
+		**out = **in
+	}
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackReceiverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(EventsReceiverSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertReceiverSpec.
+func (in *AlertReceiverSpec) DeepCopy() *AlertReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertRuleSpec) DeepCopyInto(out *AlertRuleSpec) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleSpec.
+func (in *AlertRuleSpec) DeepCopy() *AlertRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlerterConfig) DeepCopyInto(out *AlerterConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlerterConfig.
+func (in *AlerterConfig) DeepCopy() *AlerterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlerterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlerterStatus) DeepCopyInto(out *AlerterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlerterStatus.
+func (in *AlerterStatus) DeepCopy() *AlerterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlerterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingSpec) DeepCopyInto(out *AlertingSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AlertRuleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]AlertReceiverSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingSpec.
+func (in *AlertingSpec) DeepCopy() *AlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerReceiverSpec) DeepCopyInto(out *AlertmanagerReceiverSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerReceiverSpec.
+func (in *AlertmanagerReceiverSpec) DeepCopy() *AlertmanagerReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnalysisSpec) DeepCopyInto(out *AnalysisSpec) {
 	*out = *in
-	out.Stats = in.Stats
+	in.Stats.DeepCopyInto(&out.Stats)
 	if in.Outliers != nil {
 		in, out := &in.Outliers, &out.Outliers
 		*out = new(SinkSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Drift != nil {
 		in, out := &in.Drift, &out.Drift
 		*out = new(SinkSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -65,9 +195,99 @@ func (in *AvgSpec) DeepCopy() *AvgSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineDatasetSpec) DeepCopyInto(out *BaselineDatasetSpec) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineDatasetSpec.
+func (in *BaselineDatasetSpec) DeepCopy() *BaselineDatasetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineDatasetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineJobStatus) DeepCopyInto(out *BaselineJobStatus) {
+	*out = *in
+	if in.CapturedAt != nil {
+		in, out := &in.CapturedAt, &out.CapturedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RebaselinedAt != nil {
+		in, out := &in.RebaselinedAt, &out.RebaselinedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineJobStatus.
+func (in *BaselineJobStatus) DeepCopy() *BaselineJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineSource) DeepCopyInto(out *BaselineSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineSource.
+func (in *BaselineSource) DeepCopy() *BaselineSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaselineSpec) DeepCopyInto(out *BaselineSpec) {
 	*out = *in
+	if in.DescriptiveFrom != nil {
+		in, out := &in.DescriptiveFrom, &out.DescriptiveFrom
+		*out = new(BaselineSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DistributionsFrom != nil {
+		in, out := &in.DistributionsFrom, &out.DistributionsFrom
+		*out = new(BaselineSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dataset != nil {
+		in, out := &in.Dataset, &out.Dataset
+		*out = new(BaselineDatasetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Traffic != nil {
+		in, out := &in.Traffic, &out.Traffic
+		*out = new(TrafficBaselineSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineSpec.
@@ -80,6 +300,22 @@ func (in *BaselineSpec) DeepCopy() *BaselineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CorrSpec) DeepCopyInto(out *CorrSpec) {
 	*out = *in
@@ -110,6 +346,21 @@ func (in *CountSpec) DeepCopy() *CountSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CountWindowSpec) DeepCopyInto(out *CountWindowSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CountWindowSpec.
+func (in *CountWindowSpec) DeepCopy() *CountWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CountWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CovSpec) DeepCopyInto(out *CovSpec) {
 	*out = *in
@@ -173,6 +424,11 @@ func (in *DriftSpec) DeepCopyInto(out *DriftSpec) {
 		*out = new(ThresholdBasedDriftSpec)
 		**out = **in
 	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftSpec.
@@ -201,6 +457,36 @@ func (in *DriverSpec) DeepCopy() *DriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Duration) DeepCopyInto(out *Duration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Duration.
+func (in *Duration) DeepCopy() *Duration {
+	if in == nil {
+		return nil
+	}
+	out := new(Duration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventsReceiverSpec) DeepCopyInto(out *EventsReceiverSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventsReceiverSpec.
+func (in *EventsReceiverSpec) DeepCopy() *EventsReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventsReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecutorSpec) DeepCopyInto(out *ExecutorSpec) {
 	*out = *in
@@ -248,6 +534,77 @@ func (in *InferenceLoggerSpec) DeepCopy() *InferenceLoggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceLoggerStatus) DeepCopyInto(out *InferenceLoggerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceLoggerStatus.
+func (in *InferenceLoggerStatus) DeepCopy() *InferenceLoggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceLoggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceStatus) DeepCopyInto(out *InferenceServiceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceStatus.
+func (in *InferenceServiceStatus) DeepCopy() *InferenceServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceSinkSpec) DeepCopyInto(out *InferenceSinkSpec) {
+	*out = *in
+	in.Kafka.DeepCopyInto(&out.Kafka)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceSinkSpec.
+func (in *InferenceSinkSpec) DeepCopy() *InferenceSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JDBCSinkSpec) DeepCopyInto(out *JDBCSinkSpec) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JDBCSinkSpec.
+func (in *JDBCSinkSpec) DeepCopy() *JDBCSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JDBCSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobConfig) DeepCopyInto(out *JobConfig) {
 	*out = *in
@@ -268,6 +625,25 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 	*out = *in
 	out.Driver = in.Driver
 	out.Executor = in.Executor
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(RestartPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
@@ -281,350 +657,779 @@ func (in *JobSpec) DeepCopy() *JobSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
+func (in *KafkaSASLSpec) DeepCopyInto(out *KafkaSASLSpec) {
 	*out = *in
-	out.Topic = in.Topic
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSpec.
-func (in *KafkaSpec) DeepCopy() *KafkaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASLSpec.
+func (in *KafkaSASLSpec) DeepCopy() *KafkaSASLSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
-	*out = *in
+	out := new(KafkaSASLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
+	*out = *in
+	out.Topic = in.Topic
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KafkaTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSpec.
+func (in *KafkaSpec) DeepCopy() *KafkaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTLSSpec) DeepCopyInto(out *KafkaTLSSpec) {
+	*out = *in
+	if in.CACert != nil {
+		in, out := &in.CACert, &out.CACert
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTLSSpec.
+func (in *KafkaTLSSpec) DeepCopy() *KafkaTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
+func (in *KafkaTopicSpec) DeepCopy() *KafkaTopicSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicStatus) DeepCopyInto(out *KafkaTopicStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicStatus.
+func (in *KafkaTopicStatus) DeepCopy() *KafkaTopicStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxSpec) DeepCopyInto(out *MaxSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxSpec.
+func (in *MaxSpec) DeepCopy() *MaxSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeanSpec) DeepCopyInto(out *MeanSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeanSpec.
+func (in *MeanSpec) DeepCopy() *MeanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MeanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinSpec) DeepCopyInto(out *MinSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinSpec.
+func (in *MinSpec) DeepCopy() *MinSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MinSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelMonitor) DeepCopyInto(out *ModelMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitor.
+func (in *ModelMonitor) DeepCopy() *ModelMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelMonitorConfig) DeepCopyInto(out *ModelMonitorConfig) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobConfig)
+		**out = **in
+	}
+	if in.InferenceLogger != nil {
+		in, out := &in.InferenceLogger, &out.InferenceLogger
+		*out = new(InferenceLoggerConfig)
+		**out = **in
+	}
+	if in.Alerter != nil {
+		in, out := &in.Alerter, &out.Alerter
+		*out = new(AlerterConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorConfig.
+func (in *ModelMonitorConfig) DeepCopy() *ModelMonitorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelMonitorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelMonitorList) DeepCopyInto(out *ModelMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorList.
+func (in *ModelMonitorList) DeepCopy() *ModelMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelMonitorSpec) DeepCopyInto(out *ModelMonitorSpec) {
+	*out = *in
+	in.Model.DeepCopyInto(&out.Model)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.Job.DeepCopyInto(&out.Job)
+	in.InferenceLogger.DeepCopyInto(&out.InferenceLogger)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorSpec.
+func (in *ModelMonitorSpec) DeepCopy() *ModelMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelMonitorStatus) DeepCopyInto(out *ModelMonitorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.InferenceLogger = in.InferenceLogger
+	in.MonitoringJob.DeepCopyInto(&out.MonitoringJob)
+	out.InferenceService = in.InferenceService
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]KafkaTopicStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]SinkStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]RevisionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BaselineJob != nil {
+		in, out := &in.BaselineJob, &out.BaselineJob
+		*out = new(BaselineJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Alerter = in.Alerter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorStatus.
+func (in *ModelMonitorStatus) DeepCopy() *ModelMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSchemasSpec) DeepCopyInto(out *ModelSchemasSpec) {
+	*out = *in
+	in.Request.DeepCopyInto(&out.Request)
+	in.Response.DeepCopyInto(&out.Response)
+	in.Instance.DeepCopyInto(&out.Instance)
+	in.Prediction.DeepCopyInto(&out.Prediction)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSchemasSpec.
+func (in *ModelSchemasSpec) DeepCopy() *ModelSchemasSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSchemasSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int)
+		**out = **in
+	}
+	if in.Tensors != nil {
+		in, out := &in.Tensors, &out.Tensors
+		*out = new(ModelTensorsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Schemas.DeepCopyInto(&out.Schemas)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelTensorsSpec) DeepCopyInto(out *ModelTensorsSpec) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]TensorSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]TensorSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelTensorsSpec.
+func (in *ModelTensorsSpec) DeepCopy() *ModelTensorsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelTensorsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringJobStatus) DeepCopyInto(out *MonitoringJobStatus) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduledJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRestart != nil {
+		in, out := &in.LastRestart, &out.LastRestart
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRestart != nil {
+		in, out := &in.NextRestart, &out.NextRestart
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringJobStatus.
+func (in *MonitoringJobStatus) DeepCopy() *MonitoringJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	in.Trigger.DeepCopyInto(&out.Trigger)
+	in.Stats.DeepCopyInto(&out.Stats)
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(BaselineSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outliers != nil {
+		in, out := &in.Outliers, &out.Outliers
+		*out = new(OutlierSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PerRevision != nil {
+		in, out := &in.PerRevision, &out.PerRevision
+		*out = new(PerRevisionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(AlertingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
-func (in *KafkaTopicSpec) DeepCopy() *KafkaTopicSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaTopicSpec)
+	out := new(MonitoringSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MaxSpec) DeepCopyInto(out *MaxSpec) {
+func (in *OutlierSpec) DeepCopyInto(out *OutlierSpec) {
 	*out = *in
+	if in.Descriptive != nil {
+		in, out := &in.Descriptive, &out.Descriptive
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxSpec.
-func (in *MaxSpec) DeepCopy() *MaxSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierSpec.
+func (in *OutlierSpec) DeepCopy() *OutlierSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MaxSpec)
+	out := new(OutlierSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeanSpec) DeepCopyInto(out *MeanSpec) {
+func (in *PerRevisionSpec) DeepCopyInto(out *PerRevisionSpec) {
 	*out = *in
+	if in.CompareCanary != nil {
+		in, out := &in.CompareCanary, &out.CompareCanary
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeanSpec.
-func (in *MeanSpec) DeepCopy() *MeanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerRevisionSpec.
+func (in *PerRevisionSpec) DeepCopy() *PerRevisionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MeanSpec)
+	out := new(PerRevisionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MinSpec) DeepCopyInto(out *MinSpec) {
+func (in *PercSpec) DeepCopyInto(out *PercSpec) {
 	*out = *in
+	if in.Percentiles != nil {
+		in, out := &in.Percentiles, &out.Percentiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinSpec.
-func (in *MinSpec) DeepCopy() *MinSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PercSpec.
+func (in *PercSpec) DeepCopy() *PercSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MinSpec)
+	out := new(PercSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelMonitor) DeepCopyInto(out *ModelMonitor) {
+func (in *Pow2SumSpec) DeepCopyInto(out *Pow2SumSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitor.
-func (in *ModelMonitor) DeepCopy() *ModelMonitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pow2SumSpec.
+func (in *Pow2SumSpec) DeepCopy() *Pow2SumSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelMonitor)
+	out := new(Pow2SumSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelMonitor) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelMonitorConfig) DeepCopyInto(out *ModelMonitorConfig) {
+func (in *PushgatewaySinkSpec) DeepCopyInto(out *PushgatewaySinkSpec) {
 	*out = *in
-	if in.Job != nil {
-		in, out := &in.Job, &out.Job
-		*out = new(JobConfig)
-		**out = **in
-	}
-	if in.InferenceLogger != nil {
-		in, out := &in.InferenceLogger, &out.InferenceLogger
-		*out = new(InferenceLoggerConfig)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorConfig.
-func (in *ModelMonitorConfig) DeepCopy() *ModelMonitorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushgatewaySinkSpec.
+func (in *PushgatewaySinkSpec) DeepCopy() *PushgatewaySinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelMonitorConfig)
+	out := new(PushgatewaySinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelMonitorList) DeepCopyInto(out *ModelMonitorList) {
+func (in *ResourcesSpec) DeepCopyInto(out *ResourcesSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ModelMonitor, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorList.
-func (in *ModelMonitorList) DeepCopy() *ModelMonitorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesSpec.
+func (in *ResourcesSpec) DeepCopy() *ResourcesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelMonitorList)
+	out := new(ResourcesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelMonitorList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelMonitorSpec) DeepCopyInto(out *ModelMonitorSpec) {
+func (in *RestartPolicySpec) DeepCopyInto(out *RestartPolicySpec) {
 	*out = *in
-	in.Model.DeepCopyInto(&out.Model)
-	in.Monitoring.DeepCopyInto(&out.Monitoring)
-	in.Storage.DeepCopyInto(&out.Storage)
-	out.Job = in.Job
-	in.InferenceLogger.DeepCopyInto(&out.InferenceLogger)
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	out.Backoff = in.Backoff
+	out.MaxBackoff = in.MaxBackoff
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorSpec.
-func (in *ModelMonitorSpec) DeepCopy() *ModelMonitorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartPolicySpec.
+func (in *RestartPolicySpec) DeepCopy() *RestartPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelMonitorSpec)
+	out := new(RestartPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelMonitorStatus) DeepCopyInto(out *ModelMonitorStatus) {
+func (in *RevisionStatus) DeepCopyInto(out *RevisionStatus) {
 	*out = *in
+	if in.ConfiguredMetrics != nil {
+		in, out := &in.ConfiguredMetrics, &out.ConfiguredMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMonitorStatus.
-func (in *ModelMonitorStatus) DeepCopy() *ModelMonitorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionStatus.
+func (in *RevisionStatus) DeepCopy() *RevisionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelMonitorStatus)
+	out := new(RevisionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSchemasSpec) DeepCopyInto(out *ModelSchemasSpec) {
+func (in *S3SinkSpec) DeepCopyInto(out *S3SinkSpec) {
 	*out = *in
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSchemasSpec.
-func (in *ModelSchemasSpec) DeepCopy() *ModelSchemasSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SinkSpec.
+func (in *S3SinkSpec) DeepCopy() *S3SinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSchemasSpec)
+	out := new(S3SinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
 	*out = *in
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(int)
-		**out = **in
-	}
-	out.Schemas = in.Schemas
+	out.Lookback = in.Lookback
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
-func (in *ModelSpec) DeepCopy() *ModelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
+func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSpec)
+	out := new(ScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+func (in *ScheduledJobStatus) DeepCopyInto(out *ScheduledJobStatus) {
 	*out = *in
-	out.Trigger = in.Trigger
-	in.Stats.DeepCopyInto(&out.Stats)
-	if in.Baseline != nil {
-		in, out := &in.Baseline, &out.Baseline
-		*out = new(BaselineSpec)
-		**out = **in
-	}
-	if in.Outliers != nil {
-		in, out := &in.Outliers, &out.Outliers
-		*out = new(OutlierSpec)
-		(*in).DeepCopyInto(*out)
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = (*in).DeepCopy()
 	}
-	if in.Drift != nil {
-		in, out := &in.Drift, &out.Drift
-		*out = new(DriftSpec)
-		(*in).DeepCopyInto(*out)
+	if in.NextRun != nil {
+		in, out := &in.NextRun, &out.NextRun
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
-func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledJobStatus.
+func (in *ScheduledJobStatus) DeepCopy() *ScheduledJobStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringSpec)
+	out := new(ScheduledJobStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OutlierSpec) DeepCopyInto(out *OutlierSpec) {
+func (in *Schema) DeepCopyInto(out *Schema) {
 	*out = *in
-	if in.Descriptive != nil {
-		in, out := &in.Descriptive, &out.Descriptive
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]SchemaField, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierSpec.
-func (in *OutlierSpec) DeepCopy() *OutlierSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schema.
+func (in *Schema) DeepCopy() *Schema {
 	if in == nil {
 		return nil
 	}
-	out := new(OutlierSpec)
+	out := new(Schema)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PercSpec) DeepCopyInto(out *PercSpec) {
+func (in *SchemaField) DeepCopyInto(out *SchemaField) {
 	*out = *in
-	if in.Percentiles != nil {
-		in, out := &in.Percentiles, &out.Percentiles
+	if in.Nullable != nil {
+		in, out := &in.Nullable, &out.Nullable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PercSpec.
-func (in *PercSpec) DeepCopy() *PercSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaField.
+func (in *SchemaField) DeepCopy() *SchemaField {
 	if in == nil {
 		return nil
 	}
-	out := new(PercSpec)
+	out := new(SchemaField)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pow2SumSpec) DeepCopyInto(out *Pow2SumSpec) {
+func (in *SinkSpec) DeepCopyInto(out *SinkSpec) {
 	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3SinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pushgateway != nil {
+		in, out := &in.Pushgateway, &out.Pushgateway
+		*out = new(PushgatewaySinkSpec)
+		**out = **in
+	}
+	if in.JDBC != nil {
+		in, out := &in.JDBC, &out.JDBC
+		*out = new(JDBCSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookSinkSpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pow2SumSpec.
-func (in *Pow2SumSpec) DeepCopy() *Pow2SumSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkSpec.
+func (in *SinkSpec) DeepCopy() *SinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Pow2SumSpec)
+	out := new(SinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourcesSpec) DeepCopyInto(out *ResourcesSpec) {
+func (in *SinkStatus) DeepCopyInto(out *SinkStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesSpec.
-func (in *ResourcesSpec) DeepCopy() *ResourcesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkStatus.
+func (in *SinkStatus) DeepCopy() *SinkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourcesSpec)
+	out := new(SinkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SinkSpec) DeepCopyInto(out *SinkSpec) {
+func (in *SlackReceiverSpec) DeepCopyInto(out *SlackReceiverSpec) {
 	*out = *in
-	out.Kafka = in.Kafka
+	in.URL.DeepCopyInto(&out.URL)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkSpec.
-func (in *SinkSpec) DeepCopy() *SinkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackReceiverSpec.
+func (in *SlackReceiverSpec) DeepCopy() *SlackReceiverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SinkSpec)
+	out := new(SlackReceiverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -692,6 +1497,11 @@ func (in *StatSpec) DeepCopyInto(out *StatSpec) {
 		*out = new(CorrSpec)
 		**out = **in
 	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatSpec.
@@ -722,7 +1532,7 @@ func (in *StddevSpec) DeepCopy() *StddevSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 	*out = *in
-	out.Inference = in.Inference
+	in.Inference.DeepCopyInto(&out.Inference)
 	in.Analysis.DeepCopyInto(&out.Analysis)
 }
 
@@ -751,6 +1561,31 @@ func (in *SumSpec) DeepCopy() *SumSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TensorSpec) DeepCopyInto(out *TensorSpec) {
+	*out = *in
+	if in.Shape != nil {
+		in, out := &in.Shape, &out.Shape
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TensorSpec.
+func (in *TensorSpec) DeepCopy() *TensorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TensorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ThresholdBasedDriftSpec) DeepCopyInto(out *ThresholdBasedDriftSpec) {
 	*out = *in
@@ -766,10 +1601,47 @@ func (in *ThresholdBasedDriftSpec) DeepCopy() *ThresholdBasedDriftSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficBaselineSpec) DeepCopyInto(out *TrafficBaselineSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = (*in).DeepCopy()
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficBaselineSpec.
+func (in *TrafficBaselineSpec) DeepCopy() *TrafficBaselineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficBaselineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
 	*out = *in
-	out.Window = in.Window
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(WindowSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(CountWindowSpec)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSpec.
@@ -782,9 +1654,31 @@ func (in *TriggerSpec) DeepCopy() *TriggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSinkSpec) DeepCopyInto(out *WebhookSinkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSinkSpec.
+func (in *WebhookSinkSpec) DeepCopy() *WebhookSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WindowSpec) DeepCopyInto(out *WindowSpec) {
 	*out = *in
+	out.Duration = in.Duration
+	if in.Slide != nil {
+		in, out := &in.Slide, &out.Slide
+		*out = new(Duration)
+		**out = **in
+	}
+	out.WatermarkDelay = in.WatermarkDelay
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WindowSpec.
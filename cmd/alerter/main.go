@@ -0,0 +1,171 @@
+/*
+Copyright 2020 Javier de la Rúa Martínez.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// alerter evaluates the alerting rules of a ModelMonitor on the drift and outlier results written to its Kafka
+// sinks, and notifies the alerts to the receivers. It is deployed by the operator along with the Monitoring job,
+// configured through environment variables.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/javierdlrm/model-monitoring-operator/alerting"
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	log := zap.New(zap.UseDevMode(true)).WithName("alerter")
+	name := os.Getenv(constants.AlerterEnvVarModelMonitorNameLabel)
+	namespace := os.Getenv(constants.AlerterEnvVarModelMonitorNamespaceLabel)
+
+	alertingSpec := &monitoringv1beta1.AlertingSpec{}
+	if err := json.Unmarshal([]byte(os.Getenv(constants.AlerterEnvVarAlertingConfigLabel)), alertingSpec); err != nil {
+		return fmt.Errorf("Unable to parse %s: %v", constants.AlerterEnvVarAlertingConfigLabel, err)
+	}
+	sources := map[monitoringv1beta1.AlertSource]*monitoringv1beta1.KafkaSpec{}
+	if err := json.Unmarshal([]byte(os.Getenv(constants.AlerterEnvVarAlertingSourcesLabel)), &sources); err != nil {
+		return fmt.Errorf("Unable to parse %s: %v", constants.AlerterEnvVarAlertingSourcesLabel, err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	// Events are recorded on the ModelMonitor, referenced without reading it
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events(namespace)})
+	defer broadcaster.Shutdown()
+	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.DefaultAlerterName(name)})
+	modelMonitor := &corev1.ObjectReference{
+		APIVersion: monitoringv1beta1.GroupVersion.String(),
+		Kind:       "ModelMonitor",
+		Name:       name,
+		Namespace:  namespace,
+		UID:        types.UID(os.Getenv(constants.AlerterEnvVarModelMonitorUIDLabel)),
+	}
+
+	receivers, err := newReceivers(c, namespace, name, alertingSpec, recorder, modelMonitor)
+	if err != nil {
+		return err
+	}
+	evaluator, err := alerting.NewEvaluator(alertingSpec.Rules)
+	if err != nil {
+		return err
+	}
+	alerter := &alerting.Alerter{Evaluator: evaluator, Receivers: receivers, Log: log}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	// Sinks may share brokers, but are consumed independently since credentials may differ
+	errs := make(chan error, len(sources))
+	for source, kafkaSpec := range sources {
+		security, err := resources.GetKafkaSecurityConfig(c, namespace, kafkaSpec)
+		if err != nil {
+			return err
+		}
+		kafkaClient, err := kafka.NewClient(kafkaSpec.Brokers, security)
+		if err != nil {
+			return err
+		}
+		defer kafkaClient.Close()
+
+		topics := map[monitoringv1beta1.AlertSource]string{source: kafkaSpec.Topic.Name}
+		go func() {
+			errs <- alerter.Run(ctx, kafkaClient, topics, constants.AlerterStateLookback)
+		}()
+	}
+	go alerter.Resend(ctx, constants.AlerterResendInterval)
+	for range sources {
+		if err := <-errs; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newReceivers creates the receivers of the alerting spec, reading the Slack webhooks from their Secrets
+func newReceivers(c client.Client, namespace, name string, spec *monitoringv1beta1.AlertingSpec, recorder record.EventRecorder,
+	modelMonitor runtime.Object) (map[string]alerting.Receiver, error) {
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	receivers := map[string]alerting.Receiver{}
+	for _, receiver := range spec.Receivers {
+		switch {
+		case receiver.Alertmanager != nil:
+			receivers[receiver.Name] = &alerting.AlertmanagerReceiver{Client: httpClient, URL: receiver.Alertmanager.URL, Name: name, Namespace: namespace}
+		case receiver.Slack != nil:
+			secret := &corev1.Secret{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: receiver.Slack.URL.Name, Namespace: namespace}, secret); err != nil {
+				return nil, err
+			}
+			url, ok := secret.Data[receiver.Slack.URL.Key]
+			if !ok {
+				return nil, fmt.Errorf("Key %q not found in Secret %s/%s", receiver.Slack.URL.Key, namespace, receiver.Slack.URL.Name)
+			}
+			receivers[receiver.Name] = &alerting.SlackReceiver{Client: httpClient, URL: string(url), Channel: receiver.Slack.Channel, Name: name, Namespace: namespace}
+		case receiver.Events != nil:
+			receivers[receiver.Name] = &alerting.EventsReceiver{Recorder: recorder, Object: modelMonitor}
+		}
+	}
+	return receivers, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2020 Javier de la Rúa Martínez.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// infer-schema infers the model schemas of a ModelMonitor from the payloads logged in its inference topic.
+// The ModelMonitor is read from a manifest (-f) or the cluster (--namespace and --name), and either printed
+// with the inferred schemas or patched in the cluster (--patch).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+	"github.com/javierdlrm/model-monitoring-operator/inference"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+func main() {
+	var file, namespace, name, instanceFields, predictionFields string
+	var messages int
+	var timeout time.Duration
+	var patch, categoricalValues bool
+	flag.StringVar(&file, "f", "", "ModelMonitor manifest to infer the schemas for.")
+	flag.StringVar(&namespace, "namespace", "", "Namespace of the ModelMonitor in the cluster, or of the Kafka Secrets referenced by the manifest.")
+	flag.StringVar(&name, "name", "", "Name of the ModelMonitor in the cluster.")
+	flag.IntVar(&messages, "messages", 100, "Number of messages to read from the inference topic.")
+	flag.DurationVar(&timeout, "timeout", 30*time.Second, "Maximum time to wait for messages.")
+	flag.StringVar(&instanceFields, "instance-fields", "", "Comma-separated feature names of array instances, by position.")
+	flag.StringVar(&predictionFields, "prediction-fields", "", "Comma-separated names of array predictions, by position.")
+	flag.BoolVar(&categoricalValues, "categorical-values", false, "List the values seen of categorical features as their allowed values.")
+	flag.BoolVar(&patch, "patch", false, "Patch the schemas of the ModelMonitor in the cluster instead of printing it.")
+	flag.Parse()
+
+	if err := run(file, namespace, name, messages, timeout, splitFields(instanceFields), splitFields(predictionFields), categoricalValues, patch); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(file, namespace, name string, messages int, timeout time.Duration, instanceFields, predictionFields []string, categoricalValues bool, patch bool) error {
+	if (file == "") == (name == "") {
+		return fmt.Errorf("either -f or --name must be set")
+	}
+	if patch && name == "" {
+		return fmt.Errorf("--patch requires a ModelMonitor in the cluster, set --namespace and --name")
+	}
+
+	// The cluster client is only created when needed, so that manifests without Kafka Secrets work offline
+	var c client.Client
+	getClient := func() (client.Client, error) {
+		if c != nil {
+			return c, nil
+		}
+		scheme := runtime.NewScheme()
+		_ = clientgoscheme.AddToScheme(scheme)
+		_ = monitoringv1beta1.AddToScheme(scheme)
+		cfg, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		c, err = client.New(cfg, client.Options{Scheme: scheme})
+		return c, err
+	}
+
+	// Read ModelMonitor
+	modelMonitor := &monitoringv1beta1.ModelMonitor{}
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(data, modelMonitor); err != nil {
+			return fmt.Errorf("Unable to parse ModelMonitor manifest %s: %v", file, err)
+		}
+	} else {
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, modelMonitor); err != nil {
+			return err
+		}
+	}
+	if namespace == "" {
+		namespace = modelMonitor.Namespace
+	}
+
+	// Read inference payloads
+	kafkaSpec := modelMonitor.Spec.Storage.Inference.Kafka
+	if kafkaSpec.Topic.Name == "" {
+		kafkaSpec.Topic.Name = constants.DefaultKafkaTopicName(modelMonitor.Spec.Model.Name)
+	}
+	var security *kafka.SecurityConfig
+	if kafkaSpec.TLS != nil || kafkaSpec.SASL != nil {
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+		if security, err = resources.GetKafkaSecurityConfig(c, namespace, &kafkaSpec); err != nil {
+			return err
+		}
+	}
+	consumer, err := kafka.NewConsumer(kafkaSpec.Brokers, security)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	fmt.Fprintf(os.Stderr, "Reading up to %d messages from topic %s\n", messages, kafkaSpec.Topic.Name)
+	payloads, err := kafka.ReadMessages(consumer, kafkaSpec.Topic.Name, messages, timeout)
+	if err != nil {
+		return err
+	}
+
+	// Infer schemas, or tensors of v2 protocol models
+	model := modelMonitor.Spec.Model.DeepCopy()
+	var inferrer interface{ Add([]byte) error }
+	schemaInferrer := inference.NewSchemaInferrer(instanceFields, predictionFields)
+	schemaInferrer.CategoricalValues = categoricalValues
+	tensorInferrer := inference.NewTensorInferrer(instanceFields, predictionFields)
+	if model.IsV2() {
+		inferrer = tensorInferrer
+	} else {
+		inferrer = schemaInferrer
+	}
+	for _, payload := range payloads {
+		if err := inferrer.Add(payload.Value); err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping message %d of partition %d: %v\n", payload.Offset, payload.Partition, err)
+		}
+	}
+	if model.IsV2() {
+		tensors, err := tensorInferrer.Tensors()
+		if err != nil {
+			return err
+		}
+		// Schemas are derived from the tensors
+		model.Tensors = tensors
+		model.Schemas = monitoringv1beta1.ModelSchemasSpec{}
+	} else {
+		schemas, err := schemaInferrer.Schemas()
+		if err != nil {
+			return err
+		}
+		model.Schemas = *schemas
+	}
+
+	// Patch or print ModelMonitor
+	if patch {
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+		original := modelMonitor.DeepCopy()
+		modelMonitor.Spec.Model = *model
+		if err := c.Patch(context.TODO(), modelMonitor, client.MergeFrom(original)); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "ModelMonitor %s/%s patched\n", modelMonitor.Namespace, modelMonitor.Name)
+		return nil
+	}
+
+	modelMonitor.Spec.Model = *model
+	data, err := yaml.Marshal(manifest(modelMonitor))
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// manifest strips the server-side fields of a ModelMonitor so it can be applied
+func manifest(modelMonitor *monitoringv1beta1.ModelMonitor) *monitoringv1beta1.ModelMonitor {
+	out := &monitoringv1beta1.ModelMonitor{Spec: modelMonitor.Spec}
+	out.APIVersion = monitoringv1beta1.GroupVersion.String()
+	out.Kind = "ModelMonitor"
+	out.Name = modelMonitor.Name
+	out.Namespace = modelMonitor.Namespace
+	out.Labels = modelMonitor.Labels
+	out.Annotations = modelMonitor.Annotations
+	return out
+}
+
+func splitFields(fields string) []string {
+	if fields == "" {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(fields, ",") {
+		names = append(names, strings.TrimSpace(name))
+	}
+	return names
+}
@@ -1,7 +1,8 @@
 package constants
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	"time"
+
 	"knative.dev/serving/pkg/apis/autoscaling"
 )
 
@@ -12,6 +13,13 @@ var (
 	ModelMonitoringNamespace    = ModelMonitoringName + "-system"
 )
 
+// ModelMonitoring Operator environment variables
+const (
+	ModelMonitoringNamespaceEnvVar  = "MODEL_MONITORING_NAMESPACE"
+	ModelMonitorConfigMapNameEnvVar = "MODEL_MONITOR_CONFIG_MAP_NAME"
+	WatchNamespacesEnvVar           = "WATCH_NAMESPACES"
+)
+
 // ModelMonitor constants
 var (
 	ModelMonitorName          = "modelmonitor"
@@ -19,6 +27,7 @@ var (
 	ModelMonitorPodLabelKey   = ModelMonitoringAPIGroupName + "/" + ModelMonitorName
 	ModelMonitorConfigMapName = ModelMonitoringName + "-" + ModelMonitorName + "-config"
 	ModelMonitorContainerName = ModelMonitorName + "-container"
+	ModelMonitorFinalizerName = ModelMonitorAPIName + ".monitoring.hops.io/finalizer"
 )
 
 // ModelMonitor Controller Constants
@@ -34,6 +43,7 @@ type ModelMonitorComponent string
 const (
 	Job             ModelMonitorComponent = "job"
 	InferenceLogger ModelMonitorComponent = "inferenceLogger"
+	Alerter         ModelMonitorComponent = "alerter"
 )
 
 // InferenceLogger constants
@@ -41,10 +51,47 @@ const (
 	InferenceLoggerNameSuffix = "inferencelogger"
 	// Labels
 	InferenceLoggerModelLabel                          = "model"
+	InferenceLoggerEnvInferenceProtocolLabel           = "INFERENCE_PROTOCOL"
+	InferenceLoggerEnvMetadataHeadersLabel             = "INFERENCE_METADATA_HEADERS"
 	InferenceLoggerEnvKafkaBrokersLabel                = "KAFKA_BROKERS"
 	InferenceLoggerEnvKafkaTopicLabel                  = "KAFKA_TOPIC"
 	InferenceLoggerEnvKafkaTopicPartitionsLabel        = "KAFKA_TOPIC_PARTITIONS"
 	InferenceLoggerEnvKafkaTopicReplicationFactorLabel = "KAFKA_TOPIC_REPLICATION_FACTOR"
+	InferenceLoggerEnvKafkaSecurityProtocolLabel       = "KAFKA_SECURITY_PROTOCOL"
+	InferenceLoggerEnvKafkaSSLCALocationLabel          = "KAFKA_SSL_CA_LOCATION"
+	InferenceLoggerEnvKafkaSSLCertLocationLabel        = "KAFKA_SSL_CERTIFICATE_LOCATION"
+	InferenceLoggerEnvKafkaSSLKeyLocationLabel         = "KAFKA_SSL_KEY_LOCATION"
+	InferenceLoggerEnvKafkaSASLMechanismLabel          = "KAFKA_SASL_MECHANISM"
+	InferenceLoggerEnvKafkaSASLUsernameLabel           = "KAFKA_SASL_USERNAME"
+	InferenceLoggerEnvKafkaSASLPasswordLabel           = "KAFKA_SASL_PASSWORD"
+)
+
+// CloudEvent headers set by the KFServing logger on inference logs
+const (
+	CloudEventIDHeader               = "Ce-Id"
+	CloudEventTypeHeader             = "Ce-Type"
+	CloudEventSourceHeader           = "Ce-Source"
+	CloudEventInferenceServiceHeader = "Ce-Inferenceservicename"
+	CloudEventNamespaceHeader        = "Ce-Namespace"
+	// Endpoint of the logging predictor, default or canary, identifying its revision
+	CloudEventEndpointHeader = "Ce-Endpoint"
+)
+
+// InferenceLoggerMetadataHeaders are the CloudEvent headers the InferenceLogger keeps in the logged inferences
+var InferenceLoggerMetadataHeaders = []string{
+	CloudEventIDHeader,
+	CloudEventTypeHeader,
+	CloudEventSourceHeader,
+	CloudEventInferenceServiceHeader,
+	CloudEventNamespaceHeader,
+	CloudEventEndpointHeader,
+}
+
+// InferenceService constants
+const (
+	// Annotations set on the InferenceServices whose logger is wired to an InferenceLogger
+	InferenceServiceModelMonitorAnnotation   = "monitoring.hops.io/model-monitor"
+	InferenceServiceOriginalLoggerAnnotation = "monitoring.hops.io/original-logger"
 )
 
 // InferenceLogger defaults
@@ -67,11 +114,55 @@ var (
 const (
 	MonitoringJobNameSuffix = "monitoring-job"
 	// Labels
-	MonitoringJobSparkVersionLabel           = "version"
-	MonitoringJobEnvVarModelInfoLabel        = "MODEL_INFO"
-	MonitoringJobEnvVarMonitoringConfigLabel = "MONITORING_CONFIG"
-	MonitoringJobEnvVarStorageConfigLabel    = "STORAGE_CONFIG"
-	MonitoringJobEnvVarJobConfigLabel        = "JOB_CONFIG"
+	MonitoringJobSparkVersionLabel             = "version"
+	MonitoringJobEnvVarModelInfoLabel          = "MODEL_INFO"
+	MonitoringJobEnvVarMonitoringConfigLabel   = "MONITORING_CONFIG"
+	MonitoringJobEnvVarStorageConfigLabel      = "STORAGE_CONFIG"
+	MonitoringJobEnvVarJobConfigLabel          = "JOB_CONFIG"
+	MonitoringJobEnvVarSecretsPathLabel        = "SECRETS_PATH"
+	MonitoringJobEnvVarConfigMapsPathLabel     = "CONFIGMAPS_PATH"
+	MonitoringJobEnvVarBaselineRevisionLabel   = "BASELINE_REVISION"
+	MonitoringJobEnvVarRevisionHeaderLabel     = "REVISION_HEADER"
+	MonitoringJobEnvVarBaselineJobIDLabel      = "BASELINE_JOB_ID"
+	MonitoringJobEnvVarCheckpointLocationLabel = "CHECKPOINT_LOCATION"
+)
+
+// Baseline job constants. The Baseline job runs the Monitoring job image, computing the baselines from the dataset
+// or the inference traffic and writing them to the given ConfigMap.
+const (
+	BaselineJobNameSuffix            = "baseline-job"
+	BaselineConfigMapNameSuffix      = "baseline"
+	BaselineDescriptiveKey           = "descriptive.json"
+	BaselineDistributionsKey         = "distributions.json"
+	BaselineJobEnvVarDatasetLabel    = "BASELINE_DATASET"
+	BaselineJobEnvVarConfigMapLabel  = "BASELINE_CONFIGMAP"
+	BaselineJobEnvVarTrafficLabel    = "BASELINE_TRAFFIC"
+	BaselineJobEnvVarRebaselineLabel = "BASELINE_REQUEST"
+	// Annotation requesting to recompute the baselines, any new value triggers a re-baseline
+	RebaselineAnnotation = "monitoring.hops.io/rebaseline"
+)
+
+// Alerter constants. The Alerter evaluates the alerting rules on the drift and outlier results of the Monitoring job.
+const (
+	AlerterNameSuffix = "alerter"
+	AlerterAssignee   = "alerter"
+	// Labels
+	AlerterEnvVarModelMonitorNameLabel      = "MODEL_MONITOR_NAME"
+	AlerterEnvVarModelMonitorNamespaceLabel = "MODEL_MONITOR_NAMESPACE"
+	AlerterEnvVarModelMonitorUIDLabel       = "MODEL_MONITOR_UID"
+	AlerterEnvVarAlertingConfigLabel        = "ALERTING_CONFIG"
+	AlerterEnvVarAlertingSourcesLabel       = "ALERTING_SOURCES"
+	// Firing alerts are re-sent to Alertmanager, which resolves alerts not re-sent within its resolve_timeout (5m by default)
+	AlerterResendInterval = time.Minute
+	// Results evaluated on startup, without notifying, to restore the state of the alerts firing before a restart
+	AlerterStateLookback = 24 * time.Hour
+)
+
+// Alerter defaults
+var (
+	AlerterDefaultCPU            = "0.1"
+	AlerterDefaultMemory         = "128Mi"
+	AlerterDefaultReplicas int32 = 1
 )
 
 // TODO: Add Driver and Executor variables to api
@@ -91,13 +182,17 @@ var (
 	MonitoringJobExecutorCoreLimit       = "1000m"
 	MonitoringJobExecutorMemory          = "512m"
 	MonitoringJobExecutorInstances int32 = 1
-	// Volume
-	MonitoringJobVolumeName         = "test-volume"
-	MonitoringJobVolumeHostPath     = "/tmp"
-	MonitoringJobVolumeHostPathType = corev1.HostPathDirectory
-	// VolumeMount
-	MonitoringJobVolumeMountName = "test-volume"
-	MonitoringJobVolumeMountPath = "/tmp"
+	// Schedule, runs kept of each result
+	MonitoringJobRunHistoryLimit int32 = 3
+	// Restarts. Jobs running longer than the reset interval before failing start a new series of restarts
+	MonitoringJobRestartRetries       int32 = 5
+	MonitoringJobRestartBackoff             = 10 * time.Second
+	MonitoringJobRestartMaxBackoff          = 5 * time.Minute
+	MonitoringJobRestartResetInterval       = 10 * time.Minute
+	// Deployment backend
+	MonitoringJobDeploymentReplicas int32 = 1
+	// Stats
+	MonitoringStatsDefaultType = "sample" // sample or population
 	// Monitoring
 	MonitoringJobPrometheusExportDriverMetrics         = true
 	MonitoringJobPrometheusExportExecutorMetrics       = true
@@ -108,6 +203,11 @@ var (
 // KafkaTopic constants
 const (
 	KafkaTopicNameSuffix = "inference-topic"
+	// Provisioning, used when partitions or replication factor are not set
+	KafkaTopicDefaultPartitions        int32 = 1
+	KafkaTopicDefaultReplicationFactor int16 = 1
+	// Deletion of the topics of a ModelMonitor being deleted is abandoned after this time, e.g if brokers are unreachable
+	KafkaTopicDeletionTimeout = 10 * time.Minute
 	// Labels
 	KafkaTopicLabel                  = "topic"
 	KafkaBrokersLabel                = "brokers"
@@ -115,6 +215,29 @@ const (
 	KafkaTopicReplicationFactorLabel = "replicationFactor"
 )
 
+// Sinks
+const (
+	SinkProbeTimeout       = 5 * time.Second
+	StorageRecheckInterval = time.Minute
+)
+
+// Secrets referenced by the sinks, mounted at <SecretsMountPath>/<secret name>
+const (
+	SecretsMountPath   = "/etc/model-monitoring/secrets"
+	SecretVolumePrefix = "secret-"
+)
+
+// ConfigMaps referenced by the baselines, mounted at <ConfigMapsMountPath>/<config map name>
+const (
+	ConfigMapsMountPath   = "/etc/model-monitoring/configmaps"
+	ConfigMapVolumePrefix = "configmap-"
+	// Referenced baselines can be fixed outside the ModelMonitor, check them periodically
+	BaselineRecheckInterval = time.Minute
+)
+
+// Runs of scheduled Monitoring jobs are not owned by the ModelMonitor, check them periodically while running
+const ScheduledJobRecheckInterval = time.Minute
+
 // Permissions
 const (
 	ServiceAccount = "ServiceAccount"
@@ -123,6 +246,8 @@ const (
 	ServiceAccountNameSuffix = "sa"
 	RoleNameSuffix           = "r"
 	RoleBindingNameSuffix    = "rb"
+	// Label set on the permissions created by the operator, only those are deleted
+	ManagedByLabelKey = "app.kubernetes.io/managed-by"
 )
 
 // DefaultInferenceLoggerName builds a default name
@@ -130,6 +255,11 @@ func DefaultInferenceLoggerName(prefix string) string {
 	return prefix + "-" + InferenceLoggerNameSuffix
 }
 
+// DefaultInferenceLoggerURL builds the cluster-local URL of the InferenceLogger of a ModelMonitor
+func DefaultInferenceLoggerURL(modelMonitorName string, namespace string) string {
+	return "http://" + DefaultInferenceLoggerName(modelMonitorName) + "." + namespace
+}
+
 // DefaultKafkaTopicName build a default Kafka Topic name
 func DefaultKafkaTopicName(modelName string) string {
 	// Don't change. Defaults topic names must match with InferenceLogger
@@ -142,6 +272,21 @@ func DefaultMonitoringJobName(modelName string) string {
 	return modelName + "-" + MonitoringJobNameSuffix
 }
 
+// DefaultBaselineJobName builds the name of the Baseline job of a ModelMonitor
+func DefaultBaselineJobName(modelMonitorName string) string {
+	return modelMonitorName + "-" + BaselineJobNameSuffix
+}
+
+// DefaultBaselineConfigMapName builds the name of the ConfigMap the Baseline job writes the baselines to
+func DefaultBaselineConfigMapName(modelMonitorName string) string {
+	return modelMonitorName + "-" + BaselineConfigMapNameSuffix
+}
+
+// DefaultAlerterName builds the name of the Alerter of a ModelMonitor
+func DefaultAlerterName(modelMonitorName string) string {
+	return modelMonitorName + "-" + AlerterNameSuffix
+}
+
 // DefaultServiceAccountName build a default Service Account name
 func DefaultServiceAccountName(assignee string) string {
 	return assignee + "-" + ServiceAccountNameSuffix
@@ -18,23 +18,34 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"time"
 
 	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
 	"github.com/javierdlrm/model-monitoring-operator/constants"
 	"github.com/javierdlrm/model-monitoring-operator/controllers/reconcilers"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+	"github.com/javierdlrm/model-monitoring-operator/utils"
 
 	"github.com/go-logr/logr"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	kfservingv1alpha2 "github.com/kubeflow/kfserving/pkg/apis/serving/v1alpha2"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
@@ -44,21 +55,35 @@ type ModelMonitorReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// APIReader reads objects not worth caching, such as the Secrets referenced by the ModelMonitors
+	APIReader client.Reader
+	// ConfigMapNamespace and ConfigMapName locate the operator ConfigMap.
+	// A ConfigMap with the same name in the ModelMonitor namespace overrides it.
+	ConfigMapNamespace string
+	ConfigMapName      string
+	// NewKafkaClusterAdmin creates the Kafka admin clients used to manage topics
+	NewKafkaClusterAdmin kafka.NewClusterAdminFunc
+	// SinkProbeClient is the HTTP client used to check the availability of the analysis sinks, nil if disabled
+	SinkProbeClient *http.Client
 }
 
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=sparkoperator.k8s.io,resources=sparkapplications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=sparkoperator.k8s.io,resources=sparkapplications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=sparkoperator.k8s.io,resources=scheduledsparkapplications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.kubeflow.org,resources=inferenceservices,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=monitoring.hops.io,resources=modelmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.hops.io,resources=modelmonitors/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;create;
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;create;
-// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;create;
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;create;update;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;create;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;create;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=*
 // +kubebuilder:rbac:groups="",resources=pods,verbs=*
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
 
 // Reconcile reconciles ModelMonitor object request
 func (r *ModelMonitorReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -80,17 +105,92 @@ func (r *ModelMonitorReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error
 		return ctrl.Result{}, err
 	}
 
-	// Get configmap
-	configMap := &corev1.ConfigMap{}
-	if err = r.Get(ctx, types.NamespacedName{Name: constants.ModelMonitorConfigMapName, Namespace: constants.ModelMonitoringNamespace}, configMap); err != nil {
-		log.Error(err, "Failed to find ConfigMap", "name", constants.ModelMonitorConfigMapName, "namespace", constants.ModelMonitoringNamespace)
-		// Error reading the object - requeue the request.
-		return ctrl.Result{}, err
+	// Finalize ModelMonitor being deleted
+	if !modelMonitor.ObjectMeta.DeletionTimestamp.IsZero() {
+		if err = r.finalize(modelMonitor); err != nil {
+			log.Error(err, "Failed to finalize")
+			r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "FinalizeFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Register finalizer
+	if !utils.ContainsString(modelMonitor.ObjectMeta.Finalizers, constants.ModelMonitorFinalizerName) {
+		modelMonitor.ObjectMeta.Finalizers = append(modelMonitor.ObjectMeta.Finalizers, constants.ModelMonitorFinalizerName)
+		if err = r.Update(ctx, modelMonitor); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Apply defaults not yet persisted (e.g. ModelMonitors created without the webhook)
+	modelMonitor.ApplyDefaults()
+
+	// Initialize status, keeping the conditions observed so far to notify their transitions
+	previousBaseline := modelMonitor.Status.GetCondition(monitoringv1beta1.BaselineReady).DeepCopy()
+	modelMonitor.Status.ObservedGeneration = modelMonitor.Generation
+	modelMonitor.Status.InitializeConditions()
+
+	// Get config
+	modelMonitorConfig, reason, err := r.getModelMonitorConfig(ctx, modelMonitor.Namespace)
+	if err != nil {
+		if reason == "" {
+			// Error reading the object - requeue the request.
+			return ctrl.Result{}, err
+		}
+		log.Error(err, "Failed to load ModelMonitor config", "name", r.ConfigMapName)
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, reason, err.Error())
+		modelMonitor.Status.SetCondition(monitoringv1beta1.ConfigReady, corev1.ConditionFalse, reason, err.Error())
+		if err := r.updateStatus(modelMonitor); err != nil {
+			r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+			return ctrl.Result{}, err
+		}
+		// Don't requeue, ConfigMap changes are watched
+		return ctrl.Result{}, nil
 	}
+	modelMonitor.Status.SetCondition(monitoringv1beta1.ConfigReady, corev1.ConditionTrue, "", "")
 
 	// Build reconcilers
-	inferenceLoggerReconciler := reconcilers.NewInferenceLoggerReconciler(r.Client, r.Scheme, r.Log, r.Recorder, configMap)
-	monitoringJobReconciler := reconcilers.NewMonitoringJobReconciler(r.Client, r.Scheme, r.Log, r.Recorder, configMap)
+	kafkaTopicReconciler := reconcilers.NewKafkaTopicReconciler(r.Client, r.APIReader, r.Log, r.Recorder, r.NewKafkaClusterAdmin)
+	sinkReconciler := reconcilers.NewSinkReconciler(r.Log, r.SinkProbeClient)
+	baselineJobReconciler := reconcilers.NewBaselineJobReconciler(r.Client, r.Scheme, r.Log, r.Recorder, modelMonitorConfig)
+	baselineReconciler := reconcilers.NewBaselineReconciler(r.Client, r.APIReader, r.Log, modelMonitorConfig)
+	inferenceLoggerReconciler := reconcilers.NewInferenceLoggerReconciler(r.Client, r.Scheme, r.Log, r.Recorder, modelMonitorConfig)
+	inferenceServiceReconciler := reconcilers.NewInferenceServiceReconciler(r.Client, r.Log, r.Recorder)
+	monitoringJobReconciler := reconcilers.NewMonitoringJobReconciler(r.Client, r.Scheme, r.Log, r.Recorder, modelMonitorConfig)
+	alerterReconciler := reconcilers.NewAlerterReconciler(r.Client, r.Scheme, r.Log, r.Recorder, modelMonitorConfig)
+
+	// Reconcile Kafka topics
+	if err = kafkaTopicReconciler.Reconcile(modelMonitor); err != nil {
+		log.Error(err, "Failed to reconcile Kafka topics")
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "TopicProvisioningFailed", err.Error())
+		modelMonitor.Status.SetCondition(monitoringv1beta1.StorageReady, corev1.ConditionFalse, "TopicProvisioningFailed", err.Error())
+		if err := r.updateStatus(modelMonitor); err != nil {
+			r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile analysis sinks
+	if err = sinkReconciler.Reconcile(modelMonitor); err != nil {
+		log.Error(err, "Failed to reconcile sinks")
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile Baseline job
+	if err = baselineJobReconciler.Reconcile(modelMonitor); err != nil {
+		log.Error(err, "Failed to reconcile Baseline job")
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile referenced baselines
+	if err = baselineReconciler.Reconcile(modelMonitor); err != nil {
+		log.Error(err, "Failed to reconcile baselines")
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+		return ctrl.Result{}, err
+	}
 
 	// Reconcile InferenceLogger
 	if err = inferenceLoggerReconciler.Reconcile(modelMonitor); err != nil {
@@ -99,27 +199,246 @@ func (r *ModelMonitorReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error
 		return ctrl.Result{}, err
 	}
 
-	// Reconcile MonitoringJob
-	if err = monitoringJobReconciler.Reconcile(modelMonitor); err != nil {
-		log.Error(err, "Failed to reconcile")
+	// Reconcile InferenceService logger
+	if err = inferenceServiceReconciler.Reconcile(modelMonitor); err != nil {
+		log.Error(err, "Failed to reconcile InferenceService logger")
 		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
 		return ctrl.Result{}, err
 	}
 
+	// Reconcile MonitoringJob, unless the baselines are missing or invalid
+	if modelMonitor.Status.IsConditionTrue(monitoringv1beta1.BaselineReady) {
+		if err = monitoringJobReconciler.Reconcile(modelMonitor); err != nil {
+			log.Error(err, "Failed to reconcile")
+			r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+			return ctrl.Result{}, err
+		}
+	} else {
+		condition := modelMonitor.Status.GetCondition(monitoringv1beta1.BaselineReady)
+		modelMonitor.Status.SetCondition(monitoringv1beta1.MonitoringJobReady, corev1.ConditionFalse, "BaselineNotReady",
+			"Monitoring job held back until the baselines are ready")
+		// Notified once per transition, not on every requeue
+		if condition.Reason != "BaselineComputing" && (previousBaseline == nil ||
+			previousBaseline.Reason != condition.Reason || previousBaseline.Message != condition.Message) {
+			r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		}
+	}
+
+	// Reconcile Alerter
+	if err = alerterReconciler.Reconcile(modelMonitor); err != nil {
+		log.Error(err, "Failed to reconcile Alerter")
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Record the config revision rolled out
+	modelMonitor.Status.ConfigRevision = modelMonitorConfig.Revision
+
 	// Update status
-	if err = r.Status().Update(ctx, modelMonitor); err != nil {
+	if err = r.updateStatus(modelMonitor); err != nil {
 		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "InternalError", err.Error())
 		return ctrl.Result{}, err
 	}
 
+	// Terminated jobs are restarted once the backoff elapses
+	if nextRestart := modelMonitor.Status.MonitoringJob.NextRestart; nextRestart != nil {
+		requeueAfter := time.Until(nextRestart.Time)
+		if requeueAfter < time.Second {
+			requeueAfter = time.Second
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	// Storage issues can be fixed outside the cluster, check them periodically
+	if !modelMonitor.Status.IsConditionTrue(monitoringv1beta1.StorageReady) {
+		return ctrl.Result{RequeueAfter: constants.StorageRecheckInterval}, nil
+	}
+	if !modelMonitor.Status.IsConditionTrue(monitoringv1beta1.BaselineReady) {
+		return ctrl.Result{RequeueAfter: constants.BaselineRecheckInterval}, nil
+	}
+	if schedule := modelMonitor.Status.MonitoringJob.Schedule; schedule != nil && schedule.LastRunName != "" && !schedule.Finished() {
+		return ctrl.Result{RequeueAfter: constants.ScheduledJobRecheckInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
+// finalize cleans up the resources not garbage collected through owner references and removes the finalizer
+func (r *ModelMonitorReconciler) finalize(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	if !utils.ContainsString(modelMonitor.ObjectMeta.Finalizers, constants.ModelMonitorFinalizerName) {
+		return nil
+	}
+	r.Log.Info("Finalizing ModelMonitor", "namespace", modelMonitor.Namespace, "name", modelMonitor.Name)
+
+	// InferenceService logger
+	inferenceServiceReconciler := reconcilers.NewInferenceServiceReconciler(r.Client, r.Log, r.Recorder)
+	if err := inferenceServiceReconciler.Finalize(modelMonitor); err != nil {
+		return err
+	}
+
+	// Kafka topics
+	kafkaTopicReconciler := reconcilers.NewKafkaTopicReconciler(r.Client, r.APIReader, r.Log, r.Recorder, r.NewKafkaClusterAdmin)
+	if err := kafkaTopicReconciler.Finalize(modelMonitor); err != nil {
+		return err
+	}
+
+	// Monitoring job permissions
+	monitoringJobReconciler := reconcilers.NewMonitoringJobReconciler(r.Client, r.Scheme, r.Log, r.Recorder, nil)
+	if err := monitoringJobReconciler.Finalize(modelMonitor); err != nil {
+		return err
+	}
+
+	// Alerter permissions
+	alerterReconciler := reconcilers.NewAlerterReconciler(r.Client, r.Scheme, r.Log, r.Recorder, nil)
+	if err := alerterReconciler.Finalize(modelMonitor); err != nil {
+		return err
+	}
+
+	modelMonitor.ObjectMeta.Finalizers = utils.RemoveString(modelMonitor.ObjectMeta.Finalizers, constants.ModelMonitorFinalizerName)
+	return r.Update(context.TODO(), modelMonitor)
+}
+
+// getModelMonitorConfig loads the ModelMonitor config from the override ConfigMap in the given namespace or,
+// if it doesn't exist, from the operator ConfigMap. If the config is missing or invalid, the reason is returned
+// along with the error.
+func (r *ModelMonitorReconciler) getModelMonitorConfig(ctx context.Context, namespace string) (*monitoringv1beta1.ModelMonitorConfig, string, error) {
+	configMap := &corev1.ConfigMap{}
+
+	// Namespace override
+	if namespace != r.ConfigMapNamespace {
+		err := r.Get(ctx, types.NamespacedName{Name: r.ConfigMapName, Namespace: namespace}, configMap)
+		if err == nil {
+			return newModelMonitorConfig(configMap)
+		}
+		if !errors.IsNotFound(err) {
+			return nil, "", err
+		}
+	}
+
+	// Operator config
+	if err := r.Get(ctx, types.NamespacedName{Name: r.ConfigMapName, Namespace: r.ConfigMapNamespace}, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, "ConfigNotFound", err
+		}
+		return nil, "", err
+	}
+	return newModelMonitorConfig(configMap)
+}
+
+func newModelMonitorConfig(configMap *corev1.ConfigMap) (*monitoringv1beta1.ModelMonitorConfig, string, error) {
+	modelMonitorConfig, err := monitoringv1beta1.NewModelMonitorConfig(configMap)
+	if err != nil {
+		return nil, "InvalidConfig", fmt.Errorf("Invalid ConfigMap %s/%s: %v", configMap.Namespace, configMap.Name, err)
+	}
+	return modelMonitorConfig, "", nil
+}
+
+func (r *ModelMonitorReconciler) updateStatus(desired *monitoringv1beta1.ModelMonitor) error {
+	existing := &monitoringv1beta1.ModelMonitor{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		return err
+	}
+
+	// Skip the update if nothing changed. The copy in the informer's cache may be stale
+	// and we don't want to overwrite a prior status update with it.
+	if equality.Semantic.DeepEqual(existing.Status, desired.Status) {
+		return nil
+	}
+
+	if err := r.Status().Update(context.TODO(), desired); err != nil {
+		r.Log.Error(err, "Failed to update ModelMonitor status", "namespace", desired.Namespace, "name", desired.Name)
+		return err
+	}
+
+	// Notify phase transitions
+	if existing.Status.Phase != desired.Status.Phase {
+		r.Recorder.Eventf(desired, corev1.EventTypeNormal, string(desired.Status.Phase), "ModelMonitor %q is %s", desired.Name, desired.Status.Phase)
+	}
+	return nil
+}
+
 // SetupWithManager creates new managed controller
 func (r *ModelMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&monitoringv1beta1.ModelMonitor{}).
 		Owns(&knservingv1.Service{}).
-		Owns(&sparkv1beta2.SparkApplication{}).
-		Complete(r)
+		Owns(&appsv1.Deployment{}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.mapConfigMapToModelMonitors),
+		}).
+		Watches(&source.Kind{Type: &kfservingv1alpha2.InferenceService{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.mapInferenceServiceToModelMonitors),
+		})
+
+	// Spark Applications are only watched if the Spark operator is installed, the job may run on other backends
+	sparkAppKind := sparkv1beta2.SchemeGroupVersion.WithKind("SparkApplication")
+	if _, err := mgr.GetRESTMapper().RESTMapping(sparkAppKind.GroupKind(), sparkAppKind.Version); err == nil {
+		builder = builder.
+			Owns(&sparkv1beta2.SparkApplication{}).
+			Owns(&sparkv1beta2.ScheduledSparkApplication{})
+	} else if meta.IsNoMatchError(err) {
+		r.Log.Info("Spark operator not installed, only the deployment backend can run Monitoring jobs")
+	} else {
+		return err
+	}
+	return builder.Complete(r)
+}
+
+// mapInferenceServiceToModelMonitors enqueues the ModelMonitors of an InferenceService, to wire its logger and follow
+// its revisions
+func (r *ModelMonitorReconciler) mapInferenceServiceToModelMonitors(obj handler.MapObject) []ctrl.Request {
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := r.List(context.TODO(), modelMonitors, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list ModelMonitors on InferenceService change", "name", obj.Meta.GetName(), "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, modelMonitor := range modelMonitors.Items {
+		if modelMonitor.Spec.Model.Name == obj.Meta.GetName() {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: modelMonitor.Name, Namespace: modelMonitor.Namespace}})
+		}
+	}
+	return requests
+}
+
+// mapBaselineConfigMapToModelMonitors enqueues the ModelMonitors referencing baselines from a ConfigMap
+func (r *ModelMonitorReconciler) mapBaselineConfigMapToModelMonitors(obj handler.MapObject) []ctrl.Request {
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := r.List(context.TODO(), modelMonitors, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list ModelMonitors on ConfigMap change", "name", obj.Meta.GetName(), "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, modelMonitor := range modelMonitors.Items {
+		if utils.ContainsString(modelMonitor.Spec.Monitoring.ConfigMapNames(), obj.Meta.GetName()) {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: modelMonitor.Name, Namespace: modelMonitor.Namespace}})
+		}
+	}
+	return requests
+}
+
+// mapConfigMapToModelMonitors enqueues the ModelMonitors affected by a change in the operator ConfigMap, in a
+// namespace override ConfigMap or in a ConfigMap with baselines
+func (r *ModelMonitorReconciler) mapConfigMapToModelMonitors(obj handler.MapObject) []ctrl.Request {
+	if obj.Meta.GetName() != r.ConfigMapName {
+		return r.mapBaselineConfigMapToModelMonitors(obj)
+	}
+
+	var opts []client.ListOption
+	if obj.Meta.GetNamespace() != r.ConfigMapNamespace {
+		opts = append(opts, client.InNamespace(obj.Meta.GetNamespace()))
+	}
+
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := r.List(context.TODO(), modelMonitors, opts...); err != nil {
+		r.Log.Error(err, "Failed to list ModelMonitors on ConfigMap change", "name", obj.Meta.GetName(), "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	r.Log.Info("ModelMonitor config changed, enqueueing ModelMonitors", "namespace", obj.Meta.GetNamespace(), "count", len(modelMonitors.Items))
+	requests := make([]ctrl.Request, len(modelMonitors.Items))
+	for i, modelMonitor := range modelMonitors.Items {
+		requests[i] = ctrl.Request{NamespacedName: types.NamespacedName{Name: modelMonitor.Name, Namespace: modelMonitor.Namespace}}
+	}
+	return requests
 }
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/kafka/kafkatest"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kfservingv1alpha2 "github.com/kubeflow/kfserving/pkg/apis/serving/v1alpha2"
+)
+
+func newTestConfigMap(namespace string, jobImage string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: namespace},
+		Data: map[string]string{
+			"inferenceLogger": `{"containerImage": "inference-logger"}`,
+			"job":             `{"containerImage": "` + jobImage + `", "mainClass": "Monitor", "mainApplicationFile": "local:///job.jar"}`,
+		},
+	}
+}
+
+func newTestModelMonitor(namespace string) *monitoringv1beta1.ModelMonitor {
+	mm := &monitoringv1beta1.ModelMonitor{}
+	mm.Name = "test"
+	mm.Namespace = namespace
+	mm.Spec.Model.Name = "model"
+	mm.ApplyDefaults()
+	return mm
+}
+
+func newTestModelMonitorReconciler(objects ...runtime.Object) *ModelMonitorReconciler {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = monitoringv1beta1.AddToScheme(scheme)
+	_ = kfservingv1alpha2.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	return &ModelMonitorReconciler{
+		Client:               c,
+		Log:                  ctrl.Log,
+		Scheme:               scheme,
+		Recorder:             record.NewFakeRecorder(10),
+		APIReader:            c,
+		ConfigMapNamespace:   "model-monitoring-system",
+		ConfigMapName:        "config",
+		NewKafkaClusterAdmin: kafkatest.NewClusterAdminFunc(kafkatest.NewClusterAdmin()),
+	}
+}
+
+func TestModelMonitorReconcilerConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r := newTestModelMonitorReconciler(newTestConfigMap("model-monitoring-system", "job"))
+
+	config, reason, err := r.getModelMonitorConfig(context.TODO(), "default")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(reason).To(gomega.BeEmpty())
+	g.Expect(config.Job.ContainerImage).To(gomega.Equal("job"))
+
+	// The config revision is the ConfigMap resource version
+	configMap := &corev1.ConfigMap{}
+	g.Expect(r.Get(context.TODO(), types.NamespacedName{Name: "config", Namespace: "model-monitoring-system"}, configMap)).To(gomega.Succeed())
+	g.Expect(config.Revision).To(gomega.Equal(configMap.ResourceVersion))
+}
+
+func TestModelMonitorReconcilerConfigOverride(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r := newTestModelMonitorReconciler(newTestConfigMap("model-monitoring-system", "job"), newTestConfigMap("team", "team-job"))
+
+	config, reason, err := r.getModelMonitorConfig(context.TODO(), "default")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(reason).To(gomega.BeEmpty())
+	g.Expect(config.Job.ContainerImage).To(gomega.Equal("job"))
+
+	config, _, err = r.getModelMonitorConfig(context.TODO(), "team")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config.Job.ContainerImage).To(gomega.Equal("team-job"))
+}
+
+func TestModelMonitorReconcilerConfigErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r := newTestModelMonitorReconciler()
+
+	_, reason, err := r.getModelMonitorConfig(context.TODO(), "default")
+	g.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+	g.Expect(reason).To(gomega.Equal("ConfigNotFound"))
+
+	r = newTestModelMonitorReconciler(newTestConfigMap("model-monitoring-system", "job"), newTestConfigMap("default", ""))
+	_, reason, err = r.getModelMonitorConfig(context.TODO(), "default")
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(reason).To(gomega.Equal("InvalidConfig"))
+
+	null := newTestConfigMap("default", "job")
+	null.Data["job"] = "null"
+	r = newTestModelMonitorReconciler(newTestConfigMap("model-monitoring-system", "job"), null)
+	_, reason, err = r.getModelMonitorConfig(context.TODO(), "default")
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(reason).To(gomega.Equal("InvalidConfig"))
+
+	unknownBackend := newTestConfigMap("default", "job")
+	unknownBackend.Data["job"] = `{"backend": "yarn", "containerImage": "job"}`
+	r = newTestModelMonitorReconciler(newTestConfigMap("model-monitoring-system", "job"), unknownBackend)
+	_, reason, err = r.getModelMonitorConfig(context.TODO(), "default")
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(reason).To(gomega.Equal("InvalidConfig"))
+}
+
+func TestModelMonitorReconcilerMapsConfigMaps(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r := newTestModelMonitorReconciler(newTestModelMonitor("default"), newTestModelMonitor("team"))
+	mapObject := func(configMap *corev1.ConfigMap) handler.MapObject {
+		return handler.MapObject{Meta: configMap, Object: configMap}
+	}
+
+	// The operator ConfigMap affects all the ModelMonitors, an override only those in its namespace
+	g.Expect(r.mapConfigMapToModelMonitors(mapObject(newTestConfigMap("model-monitoring-system", "job")))).To(gomega.HaveLen(2))
+	g.Expect(r.mapConfigMapToModelMonitors(mapObject(newTestConfigMap("team", "job")))).To(gomega.ConsistOf(
+		ctrl.Request{NamespacedName: types.NamespacedName{Name: "test", Namespace: "team"}},
+	))
+
+	// Unreferenced ConfigMaps affect none
+	other := newTestConfigMap("default", "job")
+	other.Name = "other"
+	g.Expect(r.mapConfigMapToModelMonitors(mapObject(other))).To(gomega.BeEmpty())
+}
+
+func TestModelMonitorReconcilerFinalize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor("default")
+	mm.Finalizers = []string{constants.ModelMonitorFinalizerName}
+	r := newTestModelMonitorReconciler(mm)
+
+	g.Expect(r.finalize(mm)).To(gomega.Succeed())
+	finalized := &monitoringv1beta1.ModelMonitor{}
+	g.Expect(r.Get(context.TODO(), types.NamespacedName{Name: mm.Name, Namespace: mm.Namespace}, finalized)).To(gomega.Succeed())
+	g.Expect(finalized.Finalizers).To(gomega.BeEmpty())
+
+	// ModelMonitors without the finalizer are left untouched
+	g.Expect(r.finalize(finalized)).To(gomega.Succeed())
+}
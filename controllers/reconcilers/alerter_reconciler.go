@@ -0,0 +1,171 @@
+package reconcilers
+
+import (
+	"context"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AlerterReconciler defines a reconciler for the Alerter, evaluating the alerting rules on the analysis results
+type AlerterReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Config   *monitoringv1beta1.ModelMonitorConfig
+	Builder  *resources.AlerterBuilder
+}
+
+// NewAlerterReconciler creates a new reconciler for the Alerter
+func NewAlerterReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder,
+	config *monitoringv1beta1.ModelMonitorConfig) *AlerterReconciler {
+
+	return &AlerterReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		Log:      log,
+		Recorder: recorder,
+		Config:   config,
+		Builder:  resources.NewAlerterBuilder(config, log),
+	}
+}
+
+// Reconcile deploys the Alerter of a given ModelMonitor if alerting rules are defined, deleting it otherwise
+func (r *AlerterReconciler) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	alerterName := constants.DefaultAlerterName(modelMonitor.Name)
+
+	if modelMonitor.Spec.Monitoring.Alerting != nil && r.Config.Alerter == nil {
+		if err := r.finalizeDeployment(alerterName, modelMonitor.Namespace); err != nil {
+			return err
+		}
+		modelMonitor.Status.PropagateAlerterStatus(alerterName, nil, "AlerterNotConfigured",
+			"The alerter container image is not set in the ModelMonitor config")
+		return nil
+	}
+
+	deployment, err := r.Builder.CreateAlerterDeployment(alerterName, modelMonitor)
+	if err != nil {
+		return err
+	}
+	if deployment == nil {
+		if err = r.finalizeDeployment(alerterName, modelMonitor.Namespace); err != nil {
+			return err
+		}
+		modelMonitor.Status.PropagateAlerterStatus(alerterName, nil, "", "")
+		return nil
+	}
+
+	status, err := r.reconcileDeployment(modelMonitor, deployment)
+	if err != nil {
+		return err
+	}
+	modelMonitor.Status.PropagateAlerterStatus(alerterName, status, "", "")
+	return nil
+}
+
+func (r *AlerterReconciler) finalizeDeployment(name string, namespace string) error {
+	existing := &appsv1.Deployment{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		r.Log.Info("Deleting Alerter Deployment", "namespace", namespace, "name", name)
+		if err := r.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *AlerterReconciler) reconcileDeployment(modelMonitor *monitoringv1beta1.ModelMonitor, desired *appsv1.Deployment) (*appsv1.DeploymentStatus, error) {
+	// Set ModelMonitor as owner of desired deployment
+	if err := controllerutil.SetControllerReference(modelMonitor, desired, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	// Create service account, role and role binding if do not exist, updating the Secrets the role grants access to
+	if err := reconcileSharedPermissions(r.Client, r.Log, r.Builder.Permissions, modelMonitor); err != nil {
+		return nil, err
+	}
+
+	// Create deployment if does not exist
+	existing := &appsv1.Deployment{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Log.Info("Creating Alerter Deployment", "namespace", desired.Namespace, "name", desired.Name)
+			return &desired.Status, r.Client.Create(context.TODO(), desired)
+		}
+		return nil, err
+	}
+
+	// Return if no differences to reconcile.
+	if alerterDeploymentSemanticEquals(desired, existing) {
+		r.Log.Info("No differences found")
+		return &existing.Status, nil
+	}
+
+	r.Log.Info("Updating Alerter Deployment", "namespace", desired.Namespace, "name", desired.Name)
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Template = desired.Spec.Template
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	if err := r.Client.Update(context.TODO(), existing); err != nil {
+		return &existing.Status, err
+	}
+	return &existing.Status, nil
+}
+
+// Finalize deletes the Alerter permissions when no other ModelMonitor in the namespace defines alerting rules
+func (r *AlerterReconciler) Finalize(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	namespace := modelMonitor.Namespace
+
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := r.Client.List(context.TODO(), modelMonitors, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for _, mm := range modelMonitors.Items {
+		if mm.Name != modelMonitor.Name && mm.ObjectMeta.DeletionTimestamp.IsZero() && mm.Spec.Monitoring.Alerting != nil {
+			return nil
+		}
+	}
+
+	r.Log.Info("Deleting Alerter permissions", "namespace", namespace)
+	return finalizePermissions(r.Client, r.Log, constants.AlerterAssignee, namespace)
+}
+
+// alerterDeploymentSemanticEquals compares the fields set by the builder, ignoring the defaults set by the API server
+func alerterDeploymentSemanticEquals(desired *appsv1.Deployment, deployment *appsv1.Deployment) bool {
+	desiredPod, pod := desired.Spec.Template.Spec, deployment.Spec.Template.Spec
+	if len(desiredPod.Containers) != len(pod.Containers) || desiredPod.ServiceAccountName != pod.ServiceAccountName {
+		return false
+	}
+	for i := range desiredPod.Containers {
+		if desiredPod.Containers[i].Image != pod.Containers[i].Image ||
+			!equality.Semantic.DeepEqual(desiredPod.Containers[i].Env, pod.Containers[i].Env) ||
+			!equality.Semantic.DeepEqual(desiredPod.Containers[i].Resources, pod.Containers[i].Resources) {
+			return false
+		}
+	}
+	return equality.Semantic.DeepEqual(desired.Spec.Replicas, deployment.Spec.Replicas) &&
+		equality.Semantic.DeepEqual(desired.Spec.Template.ObjectMeta.Labels, deployment.Spec.Template.ObjectMeta.Labels) &&
+		equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, deployment.ObjectMeta.Labels)
+}
@@ -0,0 +1,117 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestAlertingModelMonitor() *monitoringv1beta1.ModelMonitor {
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.Analysis.Drift = &monitoringv1beta1.SinkSpec{Kafka: &monitoringv1beta1.KafkaSpec{
+		Brokers: "broker:9092",
+		Topic:   monitoringv1beta1.KafkaTopicSpec{Name: "drift"},
+	}}
+	mm.Spec.Monitoring.Alerting = &monitoringv1beta1.AlertingSpec{
+		Rules:     []monitoringv1beta1.AlertRuleSpec{{Name: "drift", Source: monitoringv1beta1.DriftAlertSource, Threshold: "0.3"}},
+		Receivers: []monitoringv1beta1.AlertReceiverSpec{{Name: "events", Events: &monitoringv1beta1.EventsReceiverSpec{}}},
+	}
+	return mm
+}
+
+func newTestAlerterReconciler(config *monitoringv1beta1.ModelMonitorConfig, objects ...runtime.Object) (*AlerterReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = monitoringv1beta1.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	return NewAlerterReconciler(c, scheme, ctrl.Log, record.NewFakeRecorder(10), config), c
+}
+
+func TestAlerterReconcilerDeploysAlerter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestAlertingModelMonitor()
+	r, c := newTestAlerterReconciler(&monitoringv1beta1.ModelMonitorConfig{Alerter: &monitoringv1beta1.AlerterConfig{ContainerImage: "alerter:latest"}})
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultAlerterName(mm.Name), Namespace: mm.Namespace}, deployment)).To(gomega.Succeed())
+	container := deployment.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Image).To(gomega.Equal("alerter:latest"))
+	env := map[string]string{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	sources := map[monitoringv1beta1.AlertSource]*monitoringv1beta1.KafkaSpec{}
+	g.Expect(json.Unmarshal([]byte(env[constants.AlerterEnvVarAlertingSourcesLabel]), &sources)).To(gomega.Succeed())
+	g.Expect(sources[monitoringv1beta1.DriftAlertSource].Topic.Name).To(gomega.Equal("drift"))
+
+	role := &rbacv1.Role{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultRoleName(constants.AlerterAssignee), Namespace: mm.Namespace}, role)).To(gomega.Succeed())
+	g.Expect(role.Rules).To(gomega.HaveLen(1))
+	g.Expect(role.Rules[0].Resources).To(gomega.ConsistOf("events"))
+
+	// Not ready until replicas are available, and deleted without alerting rules
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.AlerterReady).Status).To(gomega.Equal(corev1.ConditionUnknown))
+	mm.Spec.Monitoring.Alerting = nil
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultAlerterName(mm.Name), Namespace: mm.Namespace}, deployment)).NotTo(gomega.Succeed())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.AlerterReady)).To(gomega.BeNil())
+}
+
+func TestAlerterReconcilerRoleSecretNames(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	other := newTestAlertingModelMonitor()
+	other.Name = "other"
+	other.Spec.Monitoring.Alerting.Receivers = []monitoringv1beta1.AlertReceiverSpec{{Name: "slack", Slack: &monitoringv1beta1.SlackReceiverSpec{
+		URL: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "other-slack"}, Key: "url"},
+	}}}
+	mm := newTestAlertingModelMonitor()
+	mm.Spec.Storage.Analysis.Drift.Kafka.SASL = &monitoringv1beta1.KafkaSASLSpec{
+		Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-credentials"}, Key: "username"},
+		Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-credentials"}, Key: "password"},
+	}
+	r, c := newTestAlerterReconciler(&monitoringv1beta1.ModelMonitorConfig{Alerter: &monitoringv1beta1.AlerterConfig{ContainerImage: "alerter:latest"}}, other)
+
+	// Secrets of every alerting ModelMonitor in the namespace
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	role := &rbacv1.Role{}
+	roleName := types.NamespacedName{Name: constants.DefaultRoleName(constants.AlerterAssignee), Namespace: mm.Namespace}
+	g.Expect(c.Get(context.TODO(), roleName, role)).To(gomega.Succeed())
+	g.Expect(role.Rules).To(gomega.HaveLen(2))
+	g.Expect(role.Rules[1].Resources).To(gomega.ConsistOf("secrets"))
+	g.Expect(role.Rules[1].ResourceNames).To(gomega.Equal([]string{"kafka-credentials", "other-slack"}))
+
+	// Updated when the Secrets referenced change
+	mm.Spec.Storage.Analysis.Drift.Kafka.SASL = nil
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), roleName, role)).To(gomega.Succeed())
+	g.Expect(role.Rules[1].ResourceNames).To(gomega.Equal([]string{"other-slack"}))
+}
+
+func TestAlerterReconcilerNotConfigured(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestAlertingModelMonitor()
+	r, _ := newTestAlerterReconciler(&monitoringv1beta1.ModelMonitorConfig{})
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.AlerterReady).Reason).To(gomega.Equal("AlerterNotConfigured"))
+}
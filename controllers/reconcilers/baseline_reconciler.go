@@ -0,0 +1,139 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+// BaselineReconciler defines a reconciler checking the baselines referenced from ConfigMaps and Secrets
+type BaselineReconciler struct {
+	Client client.Client
+	// APIReader reads the baseline Secrets, which are not cached
+	APIReader          client.Reader
+	Log                logr.Logger
+	ModelMonitorConfig *monitoringv1beta1.ModelMonitorConfig
+}
+
+// NewBaselineReconciler creates a new reconciler for the referenced baselines
+func NewBaselineReconciler(client client.Client, apiReader client.Reader, log logr.Logger, config *monitoringv1beta1.ModelMonitorConfig) *BaselineReconciler {
+	return &BaselineReconciler{
+		Client:             client,
+		APIReader:          apiReader,
+		Log:                log,
+		ModelMonitorConfig: config,
+	}
+}
+
+// Reconcile checks that the baselines referenced from ConfigMaps and Secrets exist and match the instance schema.
+// Baselines in object stores are read by the Monitoring job only, so they are not checked.
+func (r *BaselineReconciler) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	// Baselines computed from a dataset are ready once the Baseline job completes
+	if modelMonitor.Spec.Monitoring.ComputesBaselines() {
+		if job := r.ModelMonitorConfig.Job; !job.RunsBaselineJob() {
+			modelMonitor.Status.PropagateBaselineStatus("BaselineJobUnsupported", fmt.Sprintf(
+				"Baselines can't be computed on the %s job backend, the Baseline job requires the %s backend. Reference precomputed baselines instead",
+				job.Backend, monitoringv1beta1.SparkJobBackend))
+			return nil
+		}
+		if reason, message := baselineJobProgress(modelMonitor.Status.BaselineJob); reason != "" {
+			modelMonitor.Status.PropagateBaselineStatus(reason, message)
+			return nil
+		}
+	}
+
+	instance := modelMonitor.Spec.Model.EffectiveSchemas().Instance
+
+	var reason string
+	var problems []string
+	for _, baseline := range modelMonitor.Spec.Monitoring.Baselines(&instance) {
+		if baseline.From == nil || baseline.From.URI != "" {
+			continue
+		}
+
+		data, found, err := r.getBaseline(modelMonitor.Namespace, baseline.From)
+		if err != nil {
+			return err
+		}
+		if !found {
+			reason = "BaselineNotFound"
+			problems = append(problems, fmt.Sprintf("%s baseline %s not found", baseline.Name, describeBaselineSource(baseline.From)))
+			continue
+		}
+		if err := monitoringv1beta1.ValidateBaseline(data, &instance, baseline.Features); err != nil {
+			if reason == "" {
+				reason = "InvalidBaseline"
+			}
+			problems = append(problems, fmt.Sprintf("invalid %s baseline %s: %v", baseline.Name, describeBaselineSource(baseline.From), err))
+		}
+	}
+
+	if len(problems) > 0 {
+		r.Log.Info("Baselines not ready", "namespace", modelMonitor.Namespace, "name", modelMonitor.Name, "problems", problems)
+	}
+	modelMonitor.Status.PropagateBaselineStatus(reason, strings.Join(problems, "; "))
+	return nil
+}
+
+// getBaseline reads a baseline from the key of a ConfigMap or Secret, returning whether it was found
+func (r *BaselineReconciler) getBaseline(namespace string, source *monitoringv1beta1.BaselineSource) (string, bool, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		configMap := &corev1.ConfigMap{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: source.ConfigMapKeyRef.Name, Namespace: namespace}, configMap); err != nil {
+			return "", false, ignoreNotFound(err)
+		}
+		if data, ok := configMap.Data[source.ConfigMapKeyRef.Key]; ok {
+			return data, true, nil
+		}
+		data, ok := configMap.BinaryData[source.ConfigMapKeyRef.Key]
+		return string(data), ok, nil
+	case source.SecretKeyRef != nil:
+		secret := &corev1.Secret{}
+		if err := r.APIReader.Get(context.TODO(), types.NamespacedName{Name: source.SecretKeyRef.Name, Namespace: namespace}, secret); err != nil {
+			return "", false, ignoreNotFound(err)
+		}
+		data, ok := secret.Data[source.SecretKeyRef.Key]
+		return string(data), ok, nil
+	}
+	return "", false, nil
+}
+
+// baselineJobProgress returns the reason and message of a Baseline job not completed yet
+func baselineJobProgress(status *monitoringv1beta1.BaselineJobStatus) (string, string) {
+	switch {
+	case status == nil:
+		return "BaselineComputing", "Baseline job not submitted yet"
+	case status.State == string(sparkv1beta2.FailedState) || status.State == string(sparkv1beta2.FailedSubmissionState):
+		return "BaselineJobFailed", fmt.Sprintf("Baseline job %s computing the baselines from %s", status.State, status.Source)
+	case status.State != string(sparkv1beta2.CompletedState):
+		return "BaselineComputing", fmt.Sprintf("Computing the baselines from %s", status.Source)
+	}
+	return "", ""
+}
+
+func describeBaselineSource(source *monitoringv1beta1.BaselineSource) string {
+	if source.ConfigMapKeyRef != nil {
+		return fmt.Sprintf("(ConfigMap %s, key %s)", source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+	}
+	return fmt.Sprintf("(Secret %s, key %s)", source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+}
+
+func ignoreNotFound(err error) error {
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
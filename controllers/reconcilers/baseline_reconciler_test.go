@@ -0,0 +1,110 @@
+package reconcilers
+
+import (
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestBaselineModelMonitor() *monitoringv1beta1.ModelMonitor {
+	mm := newTestModelMonitor()
+	mm.Spec.Model.Schemas = monitoringv1beta1.ModelSchemasSpec{
+		Instance: monitoringv1beta1.Schema{Fields: []monitoringv1beta1.SchemaField{
+			{Name: "sepal_length", Type: "double"},
+			{Name: "sepal_width", Type: "double"},
+		}},
+	}
+	mm.Spec.Monitoring.Baseline = &monitoringv1beta1.BaselineSpec{
+		DescriptiveFrom: &monitoringv1beta1.BaselineSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "baseline"},
+				Key:                  "descriptive.json",
+			},
+		},
+		DistributionsFrom: &monitoringv1beta1.BaselineSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "baseline"},
+				Key:                  "distributions.json",
+			},
+		},
+	}
+	return mm
+}
+
+func newTestBaselineReconciler(objects ...runtime.Object) *BaselineReconciler {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	config := &monitoringv1beta1.ModelMonitorConfig{Job: &monitoringv1beta1.JobConfig{}}
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	return NewBaselineReconciler(c, c, ctrl.Log, config)
+}
+
+func newTestBaselineObjects(descriptive string, distributions string) []runtime.Object {
+	configMap := &corev1.ConfigMap{Data: map[string]string{"descriptive.json": descriptive}}
+	configMap.Name = "baseline"
+	configMap.Namespace = "default"
+	secret := &corev1.Secret{Data: map[string][]byte{"distributions.json": []byte(distributions)}}
+	secret.Name = "baseline"
+	secret.Namespace = "default"
+	return []runtime.Object{configMap, secret}
+}
+
+func TestBaselineReconcilerAcceptsValidBaselines(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestBaselineModelMonitor()
+	r := newTestBaselineReconciler(newTestBaselineObjects(
+		`{"sepal_length": {"avg": 5.8}, "sepal_width": {"avg": 3.0}}`,
+		`{"sepal_length": {"5.0": 3}, "sepal_width": {"3.0": 4}}`)...)
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.BaselineReady)).To(gomega.BeTrue())
+}
+
+func TestBaselineReconcilerReportsMissingBaselines(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestBaselineModelMonitor()
+	r := newTestBaselineReconciler()
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	condition := mm.Status.GetCondition(monitoringv1beta1.BaselineReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal("BaselineNotFound"))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("ConfigMap baseline, key descriptive.json"))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("Secret baseline, key distributions.json"))
+}
+
+func TestBaselineReconcilerReportsInvalidBaselines(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestBaselineModelMonitor()
+	r := newTestBaselineReconciler(newTestBaselineObjects(
+		`{"sepal_length": {"avg": 5.8}, "species": {"avg": 1.0}}`,
+		`not json`)...)
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	condition := mm.Status.GetCondition(monitoringv1beta1.BaselineReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal("InvalidBaseline"))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("features not in the instance schema: species"))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("missing features: sepal_width"))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("baseline must be a JSON object by feature"))
+}
+
+func TestBaselineReconcilerSkipsInlineAndObjectStoreBaselines(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestBaselineModelMonitor()
+	mm.Spec.Monitoring.Baseline = &monitoringv1beta1.BaselineSpec{
+		Descriptive:       `{"sepal_length": {"avg": 5.8}}`,
+		DistributionsFrom: &monitoringv1beta1.BaselineSource{URI: "s3a://bucket/distributions.json"},
+	}
+
+	g.Expect(newTestBaselineReconciler().Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.BaselineReady)).To(gomega.BeTrue())
+}
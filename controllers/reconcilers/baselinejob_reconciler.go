@@ -0,0 +1,111 @@
+package reconcilers
+
+import (
+	"context"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+// BaselineJobReconciler defines a reconciler for the Baseline job, computing the baselines from a training dataset.
+// The Baseline job runs on Spark regardless of the Monitoring job backend.
+type BaselineJobReconciler struct {
+	*SparkJobBackend
+}
+
+// NewBaselineJobReconciler creates a new reconciler for the Baseline job
+func NewBaselineJobReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder,
+	config *monitoringv1beta1.ModelMonitorConfig) *BaselineJobReconciler {
+
+	return &BaselineJobReconciler{
+		SparkJobBackend: &SparkJobBackend{
+			Client:   client,
+			Scheme:   scheme,
+			Log:      log,
+			Recorder: recorder,
+			Builder:  resources.NewMonitoringJobBuilder(config, log),
+		},
+	}
+}
+
+// Reconcile runs the Baseline job of a given ModelMonitor, if the baselines are computed from a dataset or from
+// inference traffic. The job is rerun by the Spark operator when its spec changes (e.g a new dataset or a re-baseline
+// request). The job is not submitted if the configured backend does not run it, the baselines are reported as not
+// ready by the BaselineReconciler instead.
+func (r *BaselineJobReconciler) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	baselineJobName := constants.DefaultBaselineJobName(modelMonitor.Name)
+	monitoringSpec := modelMonitor.Spec.Monitoring
+
+	if !monitoringSpec.ComputesBaselines() || !r.Builder.ModelMonitorConfig.Job.RunsBaselineJob() {
+		// The ConfigMap is kept, since it may be referenced once the baselines are no longer computed
+		if err := r.finalizeSparkApp(baselineJobName, modelMonitor.Namespace); err != nil {
+			return err
+		}
+		modelMonitor.Status.PropagateBaselineJobStatus("", nil)
+		return nil
+	}
+
+	rebaseline := modelMonitor.Annotations[constants.RebaselineAnnotation]
+	if requested, err := modelMonitor.Status.RequestRebaseline(rebaseline); err != nil {
+		r.Log.Info("Invalid re-baseline request ignored", "namespace", modelMonitor.Namespace, "name", modelMonitor.Name, "rebaseline", rebaseline, "error", err.Error())
+	} else if requested {
+		r.Log.Info("Re-baseline requested", "namespace", modelMonitor.Namespace, "name", modelMonitor.Name, "rebaseline", rebaseline)
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeNormal, "RebaselineRequested", "Recomputing the baselines (%s)", rebaseline)
+	}
+
+	sparkApp, err := r.Builder.CreateBaselineJobSparkApp(baselineJobName, modelMonitor)
+	if err != nil {
+		return err
+	}
+
+	if err = r.reconcileBaselineConfigMap(modelMonitor); err != nil {
+		return err
+	}
+	sparkApp, _, err = r.reconcileSparkApp(modelMonitor, sparkApp)
+	if err != nil {
+		return err
+	}
+	status := &sparkApp.Status
+
+	source := modelMonitor.Spec.Storage.Inference.Kafka.Topic.Name
+	if monitoringSpec.Baseline.Dataset != nil {
+		source = monitoringSpec.Baseline.Dataset.URI
+	}
+	previous := modelMonitor.Status.BaselineJob.DeepCopy()
+	modelMonitor.Status.PropagateBaselineJobStatus(source, status)
+	if status.AppState.State == sparkv1beta2.CompletedState && (previous == nil || previous.State != string(sparkv1beta2.CompletedState)) {
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeNormal, "BaselineComputed", "Baselines computed from %s", source)
+	}
+	return nil
+}
+
+// reconcileBaselineConfigMap creates the ConfigMap written by the Baseline job, owned by the ModelMonitor so it is
+// garbage collected along with it
+func (r *BaselineJobReconciler) reconcileBaselineConfigMap(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	desired := r.Builder.CreateBaselineConfigMap(modelMonitor)
+	existing := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(modelMonitor, desired, r.Scheme); err != nil {
+		return err
+	}
+	r.Log.Info("Creating baseline ConfigMap", "namespace", desired.Namespace, "name", desired.Name)
+	return r.Client.Create(context.TODO(), desired)
+}
@@ -0,0 +1,153 @@
+package reconcilers
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+func newTestDatasetModelMonitor() *monitoringv1beta1.ModelMonitor {
+	mm := newTestBaselineModelMonitor()
+	mm.Spec.Monitoring.Baseline = &monitoringv1beta1.BaselineSpec{
+		Dataset: &monitoringv1beta1.BaselineDatasetSpec{URI: "s3a://bucket/iris/train.csv", Format: monitoringv1beta1.CSVDatasetFormat},
+	}
+	mm.ApplyDefaults()
+	return mm
+}
+
+func newTestBaselineJobReconciler(objects ...runtime.Object) (*BaselineJobReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = sparkv1beta2.AddToScheme(scheme)
+	_ = monitoringv1beta1.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	config := &monitoringv1beta1.ModelMonitorConfig{Job: &monitoringv1beta1.JobConfig{ContainerImage: "job:latest"}}
+	return NewBaselineJobReconciler(c, scheme, ctrl.Log, record.NewFakeRecorder(10), config), c
+}
+
+func TestBaselineJobReconcilerSubmitsJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestDatasetModelMonitor()
+	mm.Spec.Job.Volumes = []corev1.Volume{{
+		Name:         "checkpoints",
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "test-checkpoints"}},
+	}}
+	mm.Spec.Job.VolumeMounts = []corev1.VolumeMount{{Name: "checkpoints", MountPath: "/checkpoints"}}
+	r, c := newTestBaselineJobReconciler()
+
+	g.Expect(mm.Spec.Monitoring.Baseline.DescriptiveFrom.ConfigMapKeyRef.Name).To(gomega.Equal(constants.DefaultBaselineConfigMapName(mm.Name)))
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	configMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultBaselineConfigMapName(mm.Name), Namespace: mm.Namespace}, configMap)).To(gomega.Succeed())
+
+	sparkApp := &sparkv1beta2.SparkApplication{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultBaselineJobName(mm.Name), Namespace: mm.Namespace}, sparkApp)).To(gomega.Succeed())
+	env := sparkApp.Spec.Driver.EnvVars
+	g.Expect(env[constants.BaselineJobEnvVarConfigMapLabel]).To(gomega.Equal(configMap.Name))
+	g.Expect(env[constants.BaselineJobEnvVarDatasetLabel]).To(gomega.ContainSubstring("s3a://bucket/iris/train.csv"))
+	g.Expect(sparkApp.Spec.Volumes).To(gomega.BeEmpty())
+	g.Expect(sparkApp.Spec.Driver.VolumeMounts).To(gomega.BeEmpty())
+	g.Expect(sparkApp.Spec.Executor.VolumeMounts).To(gomega.BeEmpty())
+	g.Expect(mm.Status.BaselineJob.Source).To(gomega.Equal("s3a://bucket/iris/train.csv"))
+
+	// Access restricted to the baselines ConfigMap
+	role := &rbacv1.Role{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultRoleName(constants.MonitoringJobAssignee), Namespace: mm.Namespace}, role)).To(gomega.Succeed())
+	g.Expect(role.Rules).To(gomega.ContainElement(rbacv1.PolicyRule{
+		APIGroups:     []string{""},
+		Resources:     []string{"configmaps"},
+		ResourceNames: []string{configMap.Name},
+		Verbs:         []string{"get", "update"},
+	}))
+
+	// Held back until the Baseline job completes
+	g.Expect(NewBaselineReconciler(c, c, ctrl.Log, r.Builder.ModelMonitorConfig).Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.BaselineReady).Reason).To(gomega.Equal("BaselineComputing"))
+}
+
+func TestBaselineJobReconcilerDeletesJobWithoutDataset(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestDatasetModelMonitor()
+	r, c := newTestBaselineJobReconciler()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	mm.Spec.Monitoring.Baseline.Dataset = nil
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	sparkApp := &sparkv1beta2.SparkApplication{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultBaselineJobName(mm.Name), Namespace: mm.Namespace}, sparkApp)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(mm.Status.BaselineJob).To(gomega.BeNil())
+}
+
+func TestBaselineJobReconcilerRebaselinesTraffic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestBaselineModelMonitor()
+	mm.Spec.Monitoring.Baseline = &monitoringv1beta1.BaselineSpec{Traffic: &monitoringv1beta1.TrafficBaselineSpec{Windows: 10}}
+	mm.ApplyDefaults()
+	r, c := newTestBaselineJobReconciler()
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.BaselineJob.Source).To(gomega.Equal("inference"))
+	g.Expect(mm.Status.BaselineJob.RebaselinedAt).To(gomega.BeNil())
+
+	// Invalid requests are ignored
+	mm.Annotations = map[string]string{constants.RebaselineAnnotation: "now"}
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.BaselineJob.Rebaseline).To(gomega.BeEmpty())
+
+	// Requested at the time of the annotation value
+	mm.Annotations = map[string]string{constants.RebaselineAnnotation: "1700000000"}
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.BaselineJob.Rebaseline).To(gomega.Equal("1700000000"))
+	g.Expect(mm.Status.BaselineJob.RebaselinedAt.Time).To(gomega.BeTemporally("==", metav1.Unix(1700000000, 0).Time))
+
+	sparkApp := &sparkv1beta2.SparkApplication{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultBaselineJobName(mm.Name), Namespace: mm.Namespace}, sparkApp)).To(gomega.Succeed())
+	env := sparkApp.Spec.Driver.EnvVars
+	g.Expect(env[constants.BaselineJobEnvVarRebaselineLabel]).To(gomega.Equal("1700000000"))
+	g.Expect(env[constants.BaselineJobEnvVarTrafficLabel]).To(gomega.ContainSubstring(`"from":`))
+
+	// Handled once
+	rebaselinedAt := mm.Status.BaselineJob.RebaselinedAt
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.BaselineJob.RebaselinedAt).To(gomega.Equal(rebaselinedAt))
+}
+
+func TestBaselineJobReconcilerDeploymentBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestDatasetModelMonitor()
+	r, c := newTestBaselineJobReconciler()
+	r.Builder.ModelMonitorConfig.Job.Backend = monitoringv1beta1.DeploymentJobBackend
+
+	// Not submitted, reported as not ready
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	sparkApp := &sparkv1beta2.SparkApplication{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultBaselineJobName(mm.Name), Namespace: mm.Namespace}, sparkApp)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(mm.Status.BaselineJob).To(gomega.BeNil())
+
+	g.Expect(NewBaselineReconciler(c, c, ctrl.Log, r.Builder.ModelMonitorConfig).Reconcile(mm)).To(gomega.Succeed())
+	condition := mm.Status.GetCondition(monitoringv1beta1.BaselineReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal("BaselineJobUnsupported"))
+}
@@ -12,7 +12,6 @@ import (
 
 	"k8s.io/client-go/tools/record"
 
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,7 +36,7 @@ type InferenceLoggerReconciler struct {
 
 // NewInferenceLoggerReconciler creates a new reconciler for InferenceLogger
 func NewInferenceLoggerReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder,
-	config *corev1.ConfigMap) *InferenceLoggerReconciler {
+	config *monitoringv1beta1.ModelMonitorConfig) *InferenceLoggerReconciler {
 
 	return &InferenceLoggerReconciler{
 		Client:   client,
@@ -65,16 +64,16 @@ func (r *InferenceLoggerReconciler) Reconcile(modelMonitor *monitoringv1beta1.Mo
 			return err
 		}
 
-		// TODO: Modify status
+		modelMonitor.Status.PropagateInferenceLoggerStatus(nil)
 		return nil
 	}
 
-	// _, err => status, err
-	if _, err := r.reconcileService(modelMonitor, service); err != nil {
+	status, err := r.reconcileService(modelMonitor, service)
+	if err != nil {
 		return err
 	}
 
-	// TODO: Modify status
+	modelMonitor.Status.PropagateInferenceLoggerStatus(status)
 	return nil
 }
 
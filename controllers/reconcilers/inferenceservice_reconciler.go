@@ -0,0 +1,223 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kfservingv1alpha2 "github.com/kubeflow/kfserving/pkg/apis/serving/v1alpha2"
+	kfservingconstants "github.com/kubeflow/kfserving/pkg/constants"
+)
+
+// InferenceServiceReconciler defines a reconciler wiring the InferenceService predictor loggers to the InferenceLogger
+type InferenceServiceReconciler struct {
+	Client   client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// NewInferenceServiceReconciler creates a new reconciler for the InferenceService logger
+func NewInferenceServiceReconciler(client client.Client, log logr.Logger, recorder record.EventRecorder) *InferenceServiceReconciler {
+	return &InferenceServiceReconciler{
+		Client:   client,
+		Log:      log,
+		Recorder: recorder,
+	}
+}
+
+// Reconcile points the predictor loggers of the InferenceService to the InferenceLogger. The original loggers are
+// kept in an annotation to be restored on deletion. Loggers changed or removed afterwards are reported, not overwritten.
+func (r *InferenceServiceReconciler) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	name := modelMonitor.Spec.Model.Name
+	loggerURL := constants.DefaultInferenceLoggerURL(modelMonitor.Name, modelMonitor.Namespace)
+
+	isvc, err := r.getInferenceService(name, modelMonitor.Namespace)
+	if err != nil {
+		return err
+	}
+	if isvc == nil {
+		modelMonitor.Status.PropagateInferenceServiceStatus(name, nil)
+		modelMonitor.Status.PropagateRevisionsStatus(&modelMonitor.Spec.Monitoring)
+		return nil
+	}
+
+	status := &monitoringv1beta1.InferenceServiceStatus{Name: name, LoggerURL: loggerURL}
+	r.observeRevisions(modelMonitor, isvc, status)
+	if owner, ok := isvc.Annotations[constants.InferenceServiceModelMonitorAnnotation]; ok && owner != modelMonitor.Name {
+		status.LoggerURL = loggerURLOf(isvc.Spec.Default.Predictor.Logger)
+		status.Mismatch = fmt.Sprintf("logger is managed by ModelMonitor %s", owner)
+		modelMonitor.Status.PropagateInferenceServiceStatus(name, status)
+		modelMonitor.Status.PropagateRevisionsStatus(&modelMonitor.Spec.Monitoring)
+		return nil
+	}
+
+	originals, err := originalLoggers(isvc)
+	if err != nil {
+		return err
+	}
+
+	var wired, mismatches []string
+	for _, predictor := range predictors(isvc) {
+		logger := predictor.Logger
+		_, recorded := originals[predictor.endpoint]
+		switch {
+		case !recorded:
+			// Not wired yet (e.g canaries added afterwards). Endpoints are wired once, so loggers removed afterwards stay removed.
+			originals[predictor.endpoint] = logger
+			predictor.Logger = &kfservingv1alpha2.Logger{Url: &loggerURL, Mode: kfservingv1alpha2.LogAll}
+			wired = append(wired, predictor.endpoint)
+		case logger == nil:
+			mismatches = append(mismatches, fmt.Sprintf("%s predictor logger removed", predictor.endpoint))
+		case loggerURLOf(logger) != loggerURL:
+			mismatches = append(mismatches, fmt.Sprintf("%s predictor logs to %q", predictor.endpoint, loggerURLOf(logger)))
+		case logger.Mode != kfservingv1alpha2.LogAll:
+			mismatches = append(mismatches, fmt.Sprintf("%s predictor logs %s payloads only", predictor.endpoint, logger.Mode))
+		}
+	}
+	status.Mismatch = strings.Join(mismatches, "; ")
+
+	if len(wired) > 0 {
+		if err := setOriginalLoggers(isvc, originals); err != nil {
+			return err
+		}
+		isvc.Annotations[constants.InferenceServiceModelMonitorAnnotation] = modelMonitor.Name
+
+		r.Log.Info("Wiring InferenceService logger", "namespace", isvc.Namespace, "name", isvc.Name, "endpoints", wired)
+		if err := r.Client.Update(context.TODO(), isvc); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeNormal, "InferenceServiceLoggerWired",
+			"InferenceService %s %s predictors log to %s", name, strings.Join(wired, " and "), loggerURL)
+	}
+
+	modelMonitor.Status.PropagateInferenceServiceStatus(name, status)
+	modelMonitor.Status.PropagateRevisionsStatus(&modelMonitor.Spec.Monitoring)
+	return nil
+}
+
+// Finalize restores the original predictor loggers of the InferenceService, unless they were changed afterwards
+func (r *InferenceServiceReconciler) Finalize(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	isvc, err := r.getInferenceService(modelMonitor.Spec.Model.Name, modelMonitor.Namespace)
+	if err != nil || isvc == nil {
+		return err
+	}
+	if isvc.Annotations[constants.InferenceServiceModelMonitorAnnotation] != modelMonitor.Name {
+		return nil
+	}
+
+	originals, err := originalLoggers(isvc)
+	if err != nil {
+		return err
+	}
+	loggerURL := constants.DefaultInferenceLoggerURL(modelMonitor.Name, modelMonitor.Namespace)
+	for _, predictor := range predictors(isvc) {
+		if predictor.Logger != nil && loggerURLOf(predictor.Logger) == loggerURL {
+			predictor.Logger = originals[predictor.endpoint]
+		}
+	}
+	delete(isvc.Annotations, constants.InferenceServiceModelMonitorAnnotation)
+	delete(isvc.Annotations, constants.InferenceServiceOriginalLoggerAnnotation)
+
+	r.Log.Info("Restoring InferenceService logger", "namespace", isvc.Namespace, "name", isvc.Name)
+	if err := r.Client.Update(context.TODO(), isvc); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// observeRevisions records the latest ready predictor revisions and the traffic split, notifying default predictor rollouts
+func (r *InferenceServiceReconciler) observeRevisions(modelMonitor *monitoringv1beta1.ModelMonitor, isvc *kfservingv1alpha2.InferenceService,
+	status *monitoringv1beta1.InferenceServiceStatus) {
+
+	status.Revision = predictorRevision(isvc.Status.Default)
+	status.CanaryRevision = predictorRevision(isvc.Status.Canary)
+	status.Traffic = isvc.Status.Traffic
+	status.CanaryTraffic = isvc.Status.CanaryTraffic
+
+	previous := modelMonitor.Status.InferenceService.Revision
+	if previous != "" && status.Revision != "" && previous != status.Revision {
+		r.Log.Info("InferenceService revision changed", "namespace", isvc.Namespace, "name", isvc.Name, "previous", previous, "revision", status.Revision)
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeNormal, "ModelRevisionChanged", "InferenceService %s serves revision %s, previously %s (%s)",
+			isvc.Name, status.Revision, previous, modelMonitor.Spec.Model.OnVersionChange)
+	}
+}
+
+func (r *InferenceServiceReconciler) getInferenceService(name string, namespace string) (*kfservingv1alpha2.InferenceService, error) {
+	isvc := &kfservingv1alpha2.InferenceService{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, isvc); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return isvc, nil
+}
+
+// endpointPredictor defines the predictor of an InferenceService endpoint
+type endpointPredictor struct {
+	endpoint string
+	*kfservingv1alpha2.PredictorSpec
+}
+
+// predictors returns the predictors of the default and, if set, canary endpoints
+func predictors(isvc *kfservingv1alpha2.InferenceService) []endpointPredictor {
+	endpoints := []endpointPredictor{{endpoint: monitoringv1beta1.DefaultEndpoint, PredictorSpec: &isvc.Spec.Default.Predictor}}
+	if isvc.Spec.Canary != nil {
+		endpoints = append(endpoints, endpointPredictor{endpoint: monitoringv1beta1.CanaryEndpoint, PredictorSpec: &isvc.Spec.Canary.Predictor})
+	}
+	return endpoints
+}
+
+// originalLoggers returns the loggers recorded before wiring, by endpoint. Endpoints without logger are recorded as nil.
+func originalLoggers(isvc *kfservingv1alpha2.InferenceService) (map[string]*kfservingv1alpha2.Logger, error) {
+	originals := map[string]*kfservingv1alpha2.Logger{}
+	value, ok := isvc.Annotations[constants.InferenceServiceOriginalLoggerAnnotation]
+	if !ok {
+		return originals, nil
+	}
+	if err := json.Unmarshal([]byte(value), &originals); err != nil {
+		return nil, fmt.Errorf("Invalid annotation %s of InferenceService %s/%s: %v",
+			constants.InferenceServiceOriginalLoggerAnnotation, isvc.Namespace, isvc.Name, err)
+	}
+	return originals, nil
+}
+
+func setOriginalLoggers(isvc *kfservingv1alpha2.InferenceService, originals map[string]*kfservingv1alpha2.Logger) error {
+	value, err := json.Marshal(originals)
+	if err != nil {
+		return err
+	}
+	if isvc.Annotations == nil {
+		isvc.Annotations = map[string]string{}
+	}
+	isvc.Annotations[constants.InferenceServiceOriginalLoggerAnnotation] = string(value)
+	return nil
+}
+
+// predictorRevision returns the latest ready revision of the predictor of an endpoint status, if any
+func predictorRevision(statuses *kfservingv1alpha2.ComponentStatusMap) string {
+	if statuses == nil {
+		return ""
+	}
+	return (*statuses)[kfservingconstants.Predictor].Name
+}
+
+func loggerURLOf(logger *kfservingv1alpha2.Logger) string {
+	if logger == nil || logger.Url == nil {
+		return ""
+	}
+	return *logger.Url
+}
@@ -0,0 +1,179 @@
+package reconcilers
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kfservingv1alpha2 "github.com/kubeflow/kfserving/pkg/apis/serving/v1alpha2"
+	kfservingconstants "github.com/kubeflow/kfserving/pkg/constants"
+)
+
+func newTestInferenceService(logger *kfservingv1alpha2.Logger) *kfservingv1alpha2.InferenceService {
+	isvc := &kfservingv1alpha2.InferenceService{}
+	isvc.Name = "model"
+	isvc.Namespace = "default"
+	isvc.Spec.Default.Predictor.Logger = logger
+	return isvc
+}
+
+func newTestInferenceServiceReconciler(objects ...runtime.Object) (*InferenceServiceReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	_ = kfservingv1alpha2.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	return NewInferenceServiceReconciler(c, ctrl.Log, record.NewFakeRecorder(10)), c
+}
+
+func getTestInferenceService(g *gomega.WithT, c client.Client) *kfservingv1alpha2.InferenceService {
+	isvc := &kfservingv1alpha2.InferenceService{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: "model", Namespace: "default"}, isvc)).To(gomega.Succeed())
+	return isvc
+}
+
+func TestInferenceServiceReconcilerWiresAndRestoresLogger(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	originalURL := "http://message-dumper.default"
+	original := &kfservingv1alpha2.Logger{Url: &originalURL, Mode: kfservingv1alpha2.LogRequest}
+	r, c := newTestInferenceServiceReconciler(newTestInferenceService(original))
+	mm := newTestModelMonitor()
+	loggerURL := constants.DefaultInferenceLoggerURL(mm.Name, mm.Namespace)
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	isvc := getTestInferenceService(g, c)
+	g.Expect(*isvc.Spec.Default.Predictor.Logger.Url).To(gomega.Equal(loggerURL))
+	g.Expect(isvc.Spec.Default.Predictor.Logger.Mode).To(gomega.Equal(kfservingv1alpha2.LogAll))
+	g.Expect(isvc.Annotations[constants.InferenceServiceModelMonitorAnnotation]).To(gomega.Equal(mm.Name))
+	g.Expect(mm.Status.InferenceService.LoggerURL).To(gomega.Equal(loggerURL))
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.InferenceServiceLogged)).To(gomega.BeTrue())
+
+	// Canaries added afterwards are wired too
+	isvc.Spec.Canary = &kfservingv1alpha2.EndpointSpec{}
+	g.Expect(c.Update(context.TODO(), isvc)).To(gomega.Succeed())
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	isvc = getTestInferenceService(g, c)
+	g.Expect(*isvc.Spec.Canary.Predictor.Logger.Url).To(gomega.Equal(loggerURL))
+
+	g.Expect(r.Finalize(mm)).To(gomega.Succeed())
+	isvc = getTestInferenceService(g, c)
+	g.Expect(isvc.Spec.Default.Predictor.Logger).To(gomega.Equal(original))
+	g.Expect(isvc.Spec.Canary.Predictor.Logger).To(gomega.BeNil())
+	g.Expect(isvc.Annotations).NotTo(gomega.HaveKey(constants.InferenceServiceModelMonitorAnnotation))
+	g.Expect(isvc.Annotations).NotTo(gomega.HaveKey(constants.InferenceServiceOriginalLoggerAnnotation))
+}
+
+func TestInferenceServiceReconcilerReportsMismatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r, c := newTestInferenceServiceReconciler(newTestInferenceService(nil))
+	mm := newTestModelMonitor()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	// Loggers changed after wiring are not overwritten
+	otherURL := "http://other.default"
+	isvc := getTestInferenceService(g, c)
+	isvc.Spec.Default.Predictor.Logger.Url = &otherURL
+	g.Expect(c.Update(context.TODO(), isvc)).To(gomega.Succeed())
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(*getTestInferenceService(g, c).Spec.Default.Predictor.Logger.Url).To(gomega.Equal(otherURL))
+	g.Expect(mm.Status.InferenceService.Mismatch).To(gomega.ContainSubstring(otherURL))
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.InferenceServiceLogged).Reason).To(gomega.Equal("LoggerMismatch"))
+
+	// ... nor restored
+	g.Expect(r.Finalize(mm)).To(gomega.Succeed())
+	g.Expect(*getTestInferenceService(g, c).Spec.Default.Predictor.Logger.Url).To(gomega.Equal(otherURL))
+}
+
+func TestInferenceServiceReconcilerKeepsRemovedLogger(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r, c := newTestInferenceServiceReconciler(newTestInferenceService(nil))
+	mm := newTestModelMonitor()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	// Loggers removed after wiring are not wired again
+	isvc := getTestInferenceService(g, c)
+	isvc.Spec.Default.Predictor.Logger = nil
+	g.Expect(c.Update(context.TODO(), isvc)).To(gomega.Succeed())
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(getTestInferenceService(g, c).Spec.Default.Predictor.Logger).To(gomega.BeNil())
+	g.Expect(mm.Status.InferenceService.Mismatch).To(gomega.Equal("default predictor logger removed"))
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.InferenceServiceLogged).Reason).To(gomega.Equal("LoggerMismatch"))
+}
+
+func TestInferenceServiceReconcilerMissingInferenceService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	r, _ := newTestInferenceServiceReconciler()
+	mm := newTestModelMonitor()
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.InferenceServiceLogged).Reason).To(gomega.Equal("InferenceServiceNotFound"))
+	g.Expect(r.Finalize(mm)).To(gomega.Succeed())
+}
+
+func TestInferenceServiceReconcilerRecordsRevisions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := newTestInferenceService(nil)
+	isvc.Status.Default = &kfservingv1alpha2.ComponentStatusMap{kfservingconstants.Predictor: {Name: "model-predictor-default-1"}}
+	isvc.Status.Traffic = 100
+	r, c := newTestInferenceServiceReconciler(isvc)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	mm := newTestModelMonitor()
+	mm.Spec.Model.OnVersionChange = monitoringv1beta1.RestartVersionChangePolicy
+	mm.Spec.Monitoring.Drift = &monitoringv1beta1.DriftSpec{
+		Wasserstein:   &monitoringv1beta1.ThresholdBasedDriftSpec{Threshold: "0.7"},
+		JensenShannon: &monitoringv1beta1.ThresholdBasedDriftSpec{Threshold: "0.5"},
+	}
+	mm.Spec.Monitoring.PerRevision = &monitoringv1beta1.PerRevisionSpec{}
+	mm.Spec.Monitoring.ApplyDefaults(mm.Name)
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.InferenceService.Revision).To(gomega.Equal("model-predictor-default-1"))
+	g.Expect(mm.JobModelRevision()).To(gomega.Equal("model-predictor-default-1"))
+	mm.Status.MonitoringJob.ModelRevision = mm.JobModelRevision()
+
+	// Canary split
+	isvc = getTestInferenceService(g, c)
+	isvc.Status.Canary = &kfservingv1alpha2.ComponentStatusMap{kfservingconstants.Predictor: {Name: "model-predictor-canary-1"}}
+	isvc.Status.Traffic, isvc.Status.CanaryTraffic = 90, 10
+	g.Expect(c.Update(context.TODO(), isvc)).To(gomega.Succeed())
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.InferenceService.CanaryRevision).To(gomega.Equal("model-predictor-canary-1"))
+	g.Expect(mm.Status.InferenceService.CanaryTraffic).To(gomega.Equal(10))
+	g.Expect(mm.Status.Revisions).To(gomega.Equal([]monitoringv1beta1.RevisionStatus{
+		{Endpoint: monitoringv1beta1.DefaultEndpoint, Revision: "model-predictor-default-1", Traffic: 90},
+		{Endpoint: monitoringv1beta1.CanaryEndpoint, Revision: "model-predictor-canary-1", Traffic: 10, ComparedTo: monitoringv1beta1.DefaultEndpoint,
+			ConfiguredMetrics: []string{"jensenShannon", "wasserstein"}},
+	}))
+	g.Expect(mm.JobModelRevision()).To(gomega.Equal("model-predictor-default-1"))
+
+	// Rollout
+	isvc = getTestInferenceService(g, c)
+	isvc.Status.Default = &kfservingv1alpha2.ComponentStatusMap{kfservingconstants.Predictor: {Name: "model-predictor-default-2"}}
+	g.Expect(c.Update(context.TODO(), isvc)).To(gomega.Succeed())
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.JobModelRevision()).To(gomega.Equal("model-predictor-default-2"))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.ContainSubstring("InferenceServiceLoggerWired")))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.ContainSubstring("ModelRevisionChanged")))
+
+	// The last known revision is kept while the InferenceService is missing
+	g.Expect(c.Delete(context.TODO(), isvc)).To(gomega.Succeed())
+	mm.Status.MonitoringJob.ModelRevision = mm.JobModelRevision()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.JobModelRevision()).To(gomega.Equal("model-predictor-default-2"))
+
+	mm.Spec.Model.OnVersionChange = monitoringv1beta1.NoneVersionChangePolicy
+	g.Expect(mm.JobModelRevision()).To(gomega.BeEmpty())
+}
@@ -0,0 +1,266 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+
+	"github.com/Shopify/sarama"
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KafkaTopicReconciler defines a reconciler for the Kafka topics used as storage
+type KafkaTopicReconciler struct {
+	Client client.Client
+	// APIReader reads the Kafka credential Secrets
+	APIReader       client.Reader
+	Log             logr.Logger
+	Recorder        record.EventRecorder
+	NewClusterAdmin kafka.NewClusterAdminFunc
+}
+
+// NewKafkaTopicReconciler creates a new reconciler for Kafka topics
+func NewKafkaTopicReconciler(client client.Client, apiReader client.Reader, log logr.Logger, recorder record.EventRecorder,
+	newClusterAdmin kafka.NewClusterAdminFunc) *KafkaTopicReconciler {
+	return &KafkaTopicReconciler{
+		Client:          client,
+		APIReader:       apiReader,
+		Log:             log,
+		Recorder:        recorder,
+		NewClusterAdmin: newClusterAdmin,
+	}
+}
+
+// Reconcile ensures the Kafka topics of the inference and Kafka analysis sinks of a given ModelMonitor exist and match the spec.
+// Missing topics are created and topics with fewer partitions are grown, while any other mismatch is reported in status
+// without failing the storage.
+func (r *KafkaTopicReconciler) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	admins := r.newClusterAdmins(modelMonitor.Namespace)
+	defer admins.Close()
+
+	var topics []monitoringv1beta1.KafkaTopicStatus
+	for _, kafkaSpec := range modelMonitor.Spec.Storage.KafkaSpecs() {
+		status, err := r.reconcileTopic(modelMonitor, admins, kafkaSpec)
+		if err != nil {
+			return err
+		}
+		topics = append(topics, *status)
+	}
+
+	modelMonitor.Status.PropagateKafkaTopicsStatus(topics)
+	return nil
+}
+
+func (r *KafkaTopicReconciler) reconcileTopic(modelMonitor *monitoringv1beta1.ModelMonitor, admins *clusterAdmins,
+	spec *monitoringv1beta1.KafkaSpec) (*monitoringv1beta1.KafkaTopicStatus, error) {
+	admin, err := admins.Get(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create topic if does not exist
+	existing, err := kafka.DescribeTopic(admin, spec.Topic.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		partitions := spec.Topic.Partitions
+		if partitions == 0 {
+			partitions = constants.KafkaTopicDefaultPartitions
+		}
+		replicationFactor := spec.Topic.ReplicationFactor
+		if replicationFactor == 0 {
+			replicationFactor = constants.KafkaTopicDefaultReplicationFactor
+		}
+		r.Log.Info("Creating Kafka topic", "brokers", spec.Brokers, "topic", spec.Topic.Name)
+		if err := kafka.CreateTopic(admin, spec.Topic.Name, partitions, replicationFactor); err != nil {
+			return nil, err
+		}
+		return &monitoringv1beta1.KafkaTopicStatus{
+			Name:              spec.Topic.Name,
+			Brokers:           spec.Brokers,
+			Partitions:        partitions,
+			ReplicationFactor: replicationFactor,
+		}, nil
+	}
+
+	// Grow partitions if needed. Existing topics are only compared with the settings set in the spec.
+	if existing.Partitions < spec.Topic.Partitions {
+		r.Log.Info("Increasing Kafka topic partitions", "brokers", spec.Brokers, "topic", spec.Topic.Name, "from", existing.Partitions, "to", spec.Topic.Partitions)
+		if err := kafka.IncreasePartitions(admin, spec.Topic.Name, spec.Topic.Partitions); err != nil {
+			return nil, err
+		}
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeNormal, "TopicPartitionsIncreased", "Increased partitions of Kafka topic %s from %d to %d",
+			spec.Topic.Name, existing.Partitions, spec.Topic.Partitions)
+		existing.Partitions = spec.Topic.Partitions
+	}
+
+	status := &monitoringv1beta1.KafkaTopicStatus{
+		Name:              spec.Topic.Name,
+		Brokers:           spec.Brokers,
+		Partitions:        existing.Partitions,
+		ReplicationFactor: existing.ReplicationFactor,
+		Mismatch:          topicMismatch(existing, &spec.Topic),
+	}
+	if status.Mismatch != "" && status.Mismatch != previousTopicMismatch(modelMonitor, spec.Topic.Name) {
+		r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "TopicMismatch", "Kafka topic %s: %s", spec.Topic.Name, status.Mismatch)
+	}
+	return status, nil
+}
+
+// topicMismatch describes the differences with the settings of the spec that can't be reconciled
+func topicMismatch(topic *kafka.Topic, spec *monitoringv1beta1.KafkaTopicSpec) string {
+	if spec.Partitions != 0 && topic.Partitions > spec.Partitions {
+		return fmt.Sprintf("has %d partitions but %d were requested, partitions cannot be decreased", topic.Partitions, spec.Partitions)
+	}
+	if spec.ReplicationFactor != 0 && topic.ReplicationFactor != spec.ReplicationFactor {
+		return fmt.Sprintf("has replication factor %d but %d was requested", topic.ReplicationFactor, spec.ReplicationFactor)
+	}
+	return ""
+}
+
+// previousTopicMismatch returns the mismatch last reported for a topic, so it is only notified once
+func previousTopicMismatch(modelMonitor *monitoringv1beta1.ModelMonitor, name string) string {
+	for _, topic := range modelMonitor.Status.Topics {
+		if topic.Name == name {
+			return topic.Mismatch
+		}
+	}
+	return ""
+}
+
+// Finalize deletes the Kafka topics of a given ModelMonitor if its storage deletion policy is Delete. Topics used by
+// other ModelMonitors are kept. Deletion is abandoned once KafkaTopicDeletionTimeout elapses since the ModelMonitor
+// deletion, so unreachable brokers don't block it forever.
+func (r *KafkaTopicReconciler) Finalize(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	if modelMonitor.Spec.Storage.DeletionPolicy != monitoringv1beta1.DeleteDeletionPolicy {
+		return nil
+	}
+
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := r.Client.List(context.TODO(), modelMonitors); err != nil {
+		return err
+	}
+	for i := range modelMonitors.Items {
+		modelMonitors.Items[i].ApplyDefaults()
+	}
+
+	// Default topic names may not be persisted
+	defaulted := modelMonitor.DeepCopy()
+	defaulted.ApplyDefaults()
+	admins := r.newClusterAdmins(modelMonitor.Namespace)
+	defer admins.Close()
+	for _, kafkaSpec := range defaulted.Spec.Storage.KafkaSpecs() {
+		if user := topicUser(modelMonitors.Items, modelMonitor, kafkaSpec); user != nil {
+			r.Log.Info("Kafka topic used by another ModelMonitor, not deleted", "brokers", kafkaSpec.Brokers, "topic", kafkaSpec.Topic.Name,
+				"namespace", user.Namespace, "name", user.Name)
+			continue
+		}
+		if err := r.deleteTopic(admins, kafkaSpec); err != nil {
+			deletion := modelMonitor.ObjectMeta.DeletionTimestamp
+			if deletion == nil || time.Since(deletion.Time) < constants.KafkaTopicDeletionTimeout {
+				return err
+			}
+			r.Recorder.Eventf(modelMonitor, corev1.EventTypeWarning, "TopicDeletionAbandoned", "Kafka topic %s not deleted after %v: %v",
+				kafkaSpec.Topic.Name, constants.KafkaTopicDeletionTimeout, err)
+		}
+	}
+	return nil
+}
+
+// topicUser returns another ModelMonitor using the same Kafka topic, ignoring those being deleted along with their topics
+func topicUser(modelMonitors []monitoringv1beta1.ModelMonitor, modelMonitor *monitoringv1beta1.ModelMonitor, spec *monitoringv1beta1.KafkaSpec) *monitoringv1beta1.ModelMonitor {
+	for i := range modelMonitors {
+		mm := &modelMonitors[i]
+		if mm.Namespace == modelMonitor.Namespace && mm.Name == modelMonitor.Name {
+			continue
+		}
+		if !mm.ObjectMeta.DeletionTimestamp.IsZero() && mm.Spec.Storage.DeletionPolicy == monitoringv1beta1.DeleteDeletionPolicy {
+			continue
+		}
+		for _, kafkaSpec := range mm.Spec.Storage.KafkaSpecs() {
+			if kafkaSpec.Topic.Name == spec.Topic.Name && kafkaSpec.Brokers == spec.Brokers {
+				return mm
+			}
+		}
+	}
+	return nil
+}
+
+func (r *KafkaTopicReconciler) deleteTopic(admins *clusterAdmins, spec *monitoringv1beta1.KafkaSpec) error {
+	admin, err := admins.Get(spec)
+	if err != nil {
+		return err
+	}
+
+	r.Log.Info("Deleting Kafka topic", "brokers", spec.Brokers, "topic", spec.Topic.Name)
+	return kafka.DeleteTopic(admin, spec.Topic.Name)
+}
+
+// clusterAdmins shares the cluster admins opened during a reconciliation between the topics on the same brokers and
+// credentials, so each cluster is connected to once
+type clusterAdmins struct {
+	reconciler *KafkaTopicReconciler
+	namespace  string
+	admins     map[string]sarama.ClusterAdmin
+}
+
+func (r *KafkaTopicReconciler) newClusterAdmins(namespace string) *clusterAdmins {
+	return &clusterAdmins{reconciler: r, namespace: namespace, admins: map[string]sarama.ClusterAdmin{}}
+}
+
+// Get returns the cluster admin for the brokers and credentials of a given Kafka spec, creating it on first use and
+// reading the credentials from the referenced Secrets
+func (a *clusterAdmins) Get(spec *monitoringv1beta1.KafkaSpec) (sarama.ClusterAdmin, error) {
+	key, err := clusterAdminKey(spec)
+	if err != nil {
+		return nil, err
+	}
+	if admin, ok := a.admins[key]; ok {
+		return admin, nil
+	}
+
+	security, err := resources.GetKafkaSecurityConfig(a.reconciler.APIReader, a.namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := a.reconciler.NewClusterAdmin(spec.Brokers, security)
+	if err != nil {
+		return nil, err
+	}
+	a.admins[key] = admin
+	return admin, nil
+}
+
+// Close closes every cluster admin opened
+func (a *clusterAdmins) Close() {
+	for key, admin := range a.admins {
+		if err := admin.Close(); err != nil {
+			a.reconciler.Log.Error(err, "Failed to close Kafka cluster admin")
+		}
+		delete(a.admins, key)
+	}
+}
+
+// clusterAdminKey identifies the brokers and credential references of a Kafka spec
+func clusterAdminKey(spec *monitoringv1beta1.KafkaSpec) (string, error) {
+	security, err := json.Marshal(struct {
+		TLS  *monitoringv1beta1.KafkaTLSSpec  `json:"tls,omitempty"`
+		SASL *monitoringv1beta1.KafkaSASLSpec `json:"sasl,omitempty"`
+	}{spec.TLS, spec.SASL})
+	if err != nil {
+		return "", err
+	}
+	return spec.Brokers + "/" + string(security), nil
+}
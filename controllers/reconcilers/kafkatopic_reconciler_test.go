@@ -0,0 +1,202 @@
+package reconcilers
+
+import (
+	"testing"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+	"github.com/javierdlrm/model-monitoring-operator/kafka/kafkatest"
+
+	"github.com/Shopify/sarama"
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestModelMonitor() *monitoringv1beta1.ModelMonitor {
+	mm := &monitoringv1beta1.ModelMonitor{}
+	mm.Name = "test"
+	mm.Namespace = "default"
+	mm.Spec.Model.Name = "model"
+	mm.Spec.Storage.Inference.Kafka = monitoringv1beta1.KafkaSpec{
+		Brokers: "broker:9092",
+		Topic:   monitoringv1beta1.KafkaTopicSpec{Name: "inference", Partitions: 2, ReplicationFactor: 1},
+	}
+	mm.Spec.Storage.Analysis.Stats.Kafka = &monitoringv1beta1.KafkaSpec{
+		Brokers: "broker:9092",
+		Topic:   monitoringv1beta1.KafkaTopicSpec{Name: "stats"},
+	}
+	return mm
+}
+
+func newTestKafkaTopicReconciler(admin *kafkatest.ClusterAdmin, objects ...runtime.Object) *KafkaTopicReconciler {
+	return newTestKafkaTopicReconcilerWithAdminFunc(kafkatest.NewClusterAdminFunc(admin), objects...)
+}
+
+func newTestKafkaTopicReconcilerWithAdminFunc(newClusterAdmin kafka.NewClusterAdminFunc, objects ...runtime.Object) *KafkaTopicReconciler {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = monitoringv1beta1.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	return NewKafkaTopicReconciler(c, c, ctrl.Log, record.NewFakeRecorder(10), newClusterAdmin)
+}
+
+func TestKafkaTopicReconcilerCreatesTopics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	mm := newTestModelMonitor()
+
+	g.Expect(newTestKafkaTopicReconciler(admin).Reconcile(mm)).To(gomega.Succeed())
+
+	inference, ok := admin.Topic("inference")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(inference.NumPartitions).To(gomega.Equal(int32(2)))
+	stats, ok := admin.Topic("stats")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(stats.NumPartitions).To(gomega.Equal(int32(1)))
+	g.Expect(stats.ReplicationFactor).To(gomega.Equal(int16(1)))
+
+	g.Expect(mm.Status.Topics).To(gomega.HaveLen(2))
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.StorageReady)).To(gomega.BeTrue())
+}
+
+func TestKafkaTopicReconcilerGrowsPartitions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	g.Expect(admin.CreateTopic("inference", &sarama.TopicDetail{NumPartitions: 1, ReplicationFactor: 1}, false)).To(gomega.Succeed())
+	mm := newTestModelMonitor()
+
+	g.Expect(newTestKafkaTopicReconciler(admin).Reconcile(mm)).To(gomega.Succeed())
+
+	inference, _ := admin.Topic("inference")
+	g.Expect(inference.NumPartitions).To(gomega.Equal(int32(2)))
+	g.Expect(mm.Status.Topics[0].Partitions).To(gomega.Equal(int32(2)))
+	g.Expect(mm.Status.Topics[0].Mismatch).To(gomega.BeEmpty())
+}
+
+func TestKafkaTopicReconcilerReportsMismatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	g.Expect(admin.CreateTopic("inference", &sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 3}, false)).To(gomega.Succeed())
+	mm := newTestModelMonitor()
+
+	g.Expect(newTestKafkaTopicReconciler(admin).Reconcile(mm)).To(gomega.Succeed())
+
+	inference, _ := admin.Topic("inference")
+	g.Expect(inference.NumPartitions).To(gomega.Equal(int32(4)))
+	g.Expect(mm.Status.Topics[0].Mismatch).NotTo(gomega.BeEmpty())
+	condition := mm.Status.GetCondition(monitoringv1beta1.StorageReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionTrue))
+	g.Expect(condition.Reason).To(gomega.Equal("TopicMismatch"))
+	g.Expect(mm.Status.Phase).NotTo(gomega.Equal(monitoringv1beta1.ModelMonitorFailed))
+}
+
+func TestKafkaTopicReconcilerReportsMismatchOnce(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	g.Expect(admin.CreateTopic("inference", &sarama.TopicDetail{NumPartitions: 4, ReplicationFactor: 1}, false)).To(gomega.Succeed())
+	mm := newTestModelMonitor()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := newTestKafkaTopicReconciler(admin)
+	reconciler.Recorder = recorder
+
+	g.Expect(reconciler.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(reconciler.Reconcile(mm)).To(gomega.Succeed())
+
+	g.Expect(recorder.Events).To(gomega.HaveLen(1))
+	g.Expect(<-recorder.Events).To(gomega.HavePrefix(corev1.EventTypeWarning + " TopicMismatch"))
+}
+
+func TestKafkaTopicReconcilerIgnoresUnsetSettings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	g.Expect(admin.CreateTopic("stats", &sarama.TopicDetail{NumPartitions: 6, ReplicationFactor: 3}, false)).To(gomega.Succeed())
+	mm := newTestModelMonitor()
+
+	g.Expect(newTestKafkaTopicReconciler(admin).Reconcile(mm)).To(gomega.Succeed())
+
+	g.Expect(mm.Status.Topics[1]).To(gomega.Equal(monitoringv1beta1.KafkaTopicStatus{
+		Name: "stats", Brokers: "broker:9092", Partitions: 6, ReplicationFactor: 3,
+	}))
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.StorageReady).Reason).To(gomega.BeEmpty())
+}
+
+func TestKafkaTopicReconcilerFinalize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	mm := newTestModelMonitor()
+	reconciler := newTestKafkaTopicReconciler(admin)
+	g.Expect(reconciler.Reconcile(mm)).To(gomega.Succeed())
+
+	mm.Spec.Storage.DeletionPolicy = monitoringv1beta1.RetainDeletionPolicy
+	g.Expect(reconciler.Finalize(mm)).To(gomega.Succeed())
+	_, ok := admin.Topic("inference")
+	g.Expect(ok).To(gomega.BeTrue())
+
+	mm.Spec.Storage.DeletionPolicy = monitoringv1beta1.DeleteDeletionPolicy
+	g.Expect(reconciler.Finalize(mm)).To(gomega.Succeed())
+	_, ok = admin.Topic("inference")
+	g.Expect(ok).To(gomega.BeFalse())
+}
+
+func TestKafkaTopicReconcilerFinalizeKeepsSharedTopics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.DeletionPolicy = monitoringv1beta1.DeleteDeletionPolicy
+	other := newTestModelMonitor()
+	other.Name = "other"
+	other.Spec.Storage.Analysis.Stats.Kafka.Topic.Name = "other-stats"
+	reconciler := newTestKafkaTopicReconciler(admin, other)
+	g.Expect(reconciler.Reconcile(mm)).To(gomega.Succeed())
+
+	g.Expect(reconciler.Finalize(mm)).To(gomega.Succeed())
+	_, ok := admin.Topic("inference")
+	g.Expect(ok).To(gomega.BeTrue())
+	_, ok = admin.Topic("stats")
+	g.Expect(ok).To(gomega.BeFalse())
+}
+
+func TestKafkaTopicReconcilerFinalizeUnreachableBrokers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.DeletionPolicy = monitoringv1beta1.DeleteDeletionPolicy
+	reconciler := newTestKafkaTopicReconcilerWithAdminFunc(func(brokers string, security *kafka.SecurityConfig) (sarama.ClusterAdmin, error) {
+		return nil, sarama.ErrOutOfBrokers
+	})
+
+	// Retried until the deletion timeout elapses
+	deletion := metav1.NewTime(time.Now().Add(-time.Minute))
+	mm.DeletionTimestamp = &deletion
+	g.Expect(reconciler.Finalize(mm)).To(gomega.MatchError(sarama.ErrOutOfBrokers))
+
+	deletion = metav1.NewTime(time.Now().Add(-constants.KafkaTopicDeletionTimeout))
+	g.Expect(reconciler.Finalize(mm)).To(gomega.Succeed())
+}
+
+func TestKafkaTopicReconcilerSharesClusterAdmins(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	admin := kafkatest.NewClusterAdmin()
+	var brokers []string
+	reconciler := newTestKafkaTopicReconcilerWithAdminFunc(func(b string, security *kafka.SecurityConfig) (sarama.ClusterAdmin, error) {
+		brokers = append(brokers, b)
+		return admin, nil
+	})
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.Analysis.Drift = &monitoringv1beta1.SinkSpec{Kafka: &monitoringv1beta1.KafkaSpec{
+		Brokers: "other:9092",
+		Topic:   monitoringv1beta1.KafkaTopicSpec{Name: "drift"},
+	}}
+
+	g.Expect(reconciler.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.Topics).To(gomega.HaveLen(3))
+	g.Expect(brokers).To(gomega.Equal([]string{"broker:9092", "other:9092"}))
+}
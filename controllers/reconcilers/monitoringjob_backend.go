@@ -0,0 +1,48 @@
+package reconcilers
+
+import (
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// JobBackend runs the Monitoring job of a ModelMonitor on a kind of workload
+type JobBackend interface {
+	// Build builds the workload running the job, nil if the job does not run on the backend
+	Build(name string, modelMonitor *monitoringv1beta1.ModelMonitor) (runtime.Object, error)
+	// Reconcile creates or updates the workload of the job, returning the existing one
+	Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor, desired runtime.Object) (runtime.Object, error)
+	// Status propagates the status of the workload of the job, nil if not built, to the ModelMonitor
+	Status(modelMonitor *monitoringv1beta1.ModelMonitor, workload runtime.Object) error
+	// Delete deletes the workloads of the job, if any
+	Delete(name string, namespace string) error
+}
+
+// newJobBackends creates the backend configured to run the Monitoring job, along with the other backends
+func newJobBackends(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder,
+	config *monitoringv1beta1.ModelMonitorConfig, builder *resources.MonitoringJobBuilder) (JobBackend, []JobBackend) {
+
+	spark := &SparkJobBackend{Client: client, Scheme: scheme, Log: log, Recorder: recorder, Builder: builder}
+	deployment := &DeploymentJobBackend{Client: client, Scheme: scheme, Log: log, Builder: builder}
+
+	if config != nil && config.Job != nil && config.Job.Backend == monitoringv1beta1.DeploymentJobBackend {
+		return deployment, []JobBackend{spark}
+	}
+	return spark, []JobBackend{deployment}
+}
+
+// ignoreNoMatch ignores the errors of kinds not installed in the cluster (e.g Spark Applications without the Spark
+// operator), which have no workloads to delete
+func ignoreNoMatch(err error) error {
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
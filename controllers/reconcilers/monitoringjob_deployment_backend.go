@@ -0,0 +1,175 @@
+package reconcilers
+
+import (
+	"context"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// DeploymentJobBackend runs the streaming Monitoring job as a Deployment, restarted by Kubernetes when it fails.
+// Scheduled jobs are not supported.
+type DeploymentJobBackend struct {
+	Client  client.Client
+	Scheme  *runtime.Scheme
+	Log     logr.Logger
+	Builder *resources.MonitoringJobBuilder
+}
+
+// Build builds the Deployment of the job, nil if the job is scheduled
+func (b *DeploymentJobBackend) Build(name string, modelMonitor *monitoringv1beta1.ModelMonitor) (runtime.Object, error) {
+	deployment, err := b.Builder.CreateMonitoringJobDeployment(name, modelMonitor)
+	if err != nil || deployment == nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// Reconcile creates or updates the Deployment of the job
+func (b *DeploymentJobBackend) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor, desired runtime.Object) (runtime.Object, error) {
+	deployment, ok := desired.(*appsv1.Deployment)
+	if !ok {
+		return nil, nil
+	}
+	return b.reconcileDeployment(modelMonitor, deployment)
+}
+
+// Status propagates the availability of the Deployment of the job
+func (b *DeploymentJobBackend) Status(modelMonitor *monitoringv1beta1.ModelMonitor, workload runtime.Object) error {
+	if deployment, ok := workload.(*appsv1.Deployment); ok {
+		modelMonitor.Status.PropagateMonitoringJobDeploymentStatus(&deployment.Status, "", "")
+		modelMonitor.Status.MonitoringJob.ModelRevision = modelMonitor.JobModelRevision()
+		return nil
+	}
+	if modelMonitor.Spec.Monitoring.Trigger.Schedule != nil {
+		modelMonitor.Status.PropagateMonitoringJobDeploymentStatus(nil, "ScheduleNotSupported",
+			"Scheduled jobs require the spark backend in the ModelMonitor config")
+		return nil
+	}
+	modelMonitor.Status.PropagateMonitoringJobDeploymentStatus(nil, "", "")
+	return nil
+}
+
+// Delete deletes the Deployment of the job
+func (b *DeploymentJobBackend) Delete(name string, namespace string) error {
+	existing := &appsv1.Deployment{}
+	if err := b.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		b.Log.Info("Deleting Monitoring job Deployment", "namespace", namespace, "name", name)
+		if err := b.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *DeploymentJobBackend) reconcileDeployment(modelMonitor *monitoringv1beta1.ModelMonitor, desired *appsv1.Deployment) (*appsv1.Deployment, error) {
+	// Set ModelMonitor as owner of desired deployment
+	if err := controllerutil.SetControllerReference(modelMonitor, desired, b.Scheme); err != nil {
+		return nil, err
+	}
+
+	// Create deployment if does not exist
+	existing := &appsv1.Deployment{}
+	err := b.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Create service account, role and role binding if do not exist
+			if err := reconcileSharedPermissions(b.Client, b.Log, b.Builder.Permissions, modelMonitor); err != nil {
+				return desired, err
+			}
+
+			b.Log.Info("Creating Monitoring job Deployment", "namespace", desired.Namespace, "name", desired.Name)
+			return desired, b.Client.Create(context.TODO(), desired)
+		}
+		return nil, err
+	}
+
+	// Return if no differences to reconcile.
+	if jobDeploymentSemanticEquals(desired, existing) {
+		b.Log.Info("No differences found")
+		return existing, nil
+	}
+
+	b.Log.Info("Updating Monitoring job Deployment", "namespace", desired.Namespace, "name", desired.Name)
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Strategy = desired.Spec.Strategy
+	existing.Spec.Template = desired.Spec.Template
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	if err := b.Client.Update(context.TODO(), existing); err != nil {
+		return existing, err
+	}
+	return existing, nil
+}
+
+// jobDeploymentSemanticEquals compares the fields set by the builder, ignoring the defaults set by the API server
+func jobDeploymentSemanticEquals(desired *appsv1.Deployment, deployment *appsv1.Deployment) bool {
+	desiredPod, pod := desired.Spec.Template.Spec, deployment.Spec.Template.Spec
+	if len(desiredPod.Containers) != len(pod.Containers) || desiredPod.ServiceAccountName != pod.ServiceAccountName ||
+		!volumesSemanticEquals(desiredPod.Volumes, pod.Volumes) {
+		return false
+	}
+	for i := range desiredPod.Containers {
+		if desiredPod.Containers[i].Image != pod.Containers[i].Image ||
+			!equality.Semantic.DeepEqual(desiredPod.Containers[i].Env, pod.Containers[i].Env) ||
+			!equality.Semantic.DeepEqual(desiredPod.Containers[i].Resources, pod.Containers[i].Resources) ||
+			!equality.Semantic.DeepEqual(desiredPod.Containers[i].VolumeMounts, pod.Containers[i].VolumeMounts) {
+			return false
+		}
+	}
+	return equality.Semantic.DeepEqual(desired.Spec.Replicas, deployment.Spec.Replicas) &&
+		desired.Spec.Strategy.Type == deployment.Spec.Strategy.Type &&
+		equality.Semantic.DeepEqual(desired.Spec.Template.ObjectMeta.Labels, deployment.Spec.Template.ObjectMeta.Labels) &&
+		equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, deployment.ObjectMeta.Labels)
+}
+
+// volumesSemanticEquals compares the volumes by name and source, ignoring the defaults set by the API server (e.g the
+// mode of Secret and ConfigMap volumes)
+func volumesSemanticEquals(desired []corev1.Volume, volumes []corev1.Volume) bool {
+	if len(desired) != len(volumes) {
+		return false
+	}
+	for i := range desired {
+		desiredVolume, volume := desired[i], volumes[i]
+		if desiredVolume.Name != volume.Name {
+			return false
+		}
+		switch {
+		case desiredVolume.Secret != nil:
+			if volume.Secret == nil || desiredVolume.Secret.SecretName != volume.Secret.SecretName {
+				return false
+			}
+		case desiredVolume.ConfigMap != nil:
+			if volume.ConfigMap == nil || desiredVolume.ConfigMap.Name != volume.ConfigMap.Name {
+				return false
+			}
+		case desiredVolume.HostPath != nil:
+			if volume.HostPath == nil || desiredVolume.HostPath.Path != volume.HostPath.Path {
+				return false
+			}
+		default:
+			if !equality.Semantic.DeepEqual(desiredVolume.VolumeSource, volume.VolumeSource) {
+				return false
+			}
+		}
+	}
+	return true
+}
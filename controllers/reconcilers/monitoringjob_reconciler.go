@@ -20,30 +20,34 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-
-	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
 )
 
-// MonitoringJobReconciler defines a reconciler for Monitoring job
+// MonitoringJobReconciler defines a reconciler for Monitoring job, run on the backend set in the ModelMonitor config
 type MonitoringJobReconciler struct {
 	Client   client.Client
 	Scheme   *runtime.Scheme
 	Log      logr.Logger
 	Recorder record.EventRecorder
 	Builder  *resources.MonitoringJobBuilder
+	Backend  JobBackend
+	// Backends not configured, whose workloads are deleted after a backend change
+	OtherBackends []JobBackend
 }
 
 // NewMonitoringJobReconciler creates a new reconciler for Monitoring job
 func NewMonitoringJobReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder,
-	config *corev1.ConfigMap) *MonitoringJobReconciler {
+	config *monitoringv1beta1.ModelMonitorConfig) *MonitoringJobReconciler {
 
+	builder := resources.NewMonitoringJobBuilder(config, log)
+	backend, otherBackends := newJobBackends(client, scheme, log, recorder, config, builder)
 	return &MonitoringJobReconciler{
-		Client:   client,
-		Scheme:   scheme,
-		Log:      log,
-		Recorder: recorder,
-		Builder:  resources.NewMonitoringJobBuilder(config, log),
+		Client:        client,
+		Scheme:        scheme,
+		Log:           log,
+		Recorder:      recorder,
+		Builder:       builder,
+		Backend:       backend,
+		OtherBackends: otherBackends,
 	}
 }
 
@@ -52,100 +56,74 @@ func (r *MonitoringJobReconciler) Reconcile(modelMonitor *monitoringv1beta1.Mode
 	monitoringJobName := constants.DefaultMonitoringJobName(modelMonitor.Name)
 	r.Log = r.Log.WithValues("monitoringJob", modelMonitor.Namespace+"/"+modelMonitor.Name, "monitoringJobName", monitoringJobName)
 
-	var sparkApp *sparkv1beta2.SparkApplication
-	var err error
-	sparkApp, err = r.Builder.CreateMonitoringJobSparkApp(monitoringJobName, modelMonitor)
-	if err != nil {
-		return err
-	}
-
-	if sparkApp == nil {
-		if err = r.finalizeSparkApp(monitoringJobName, modelMonitor.Namespace); err != nil {
+	for _, backend := range r.OtherBackends {
+		if err := backend.Delete(monitoringJobName, modelMonitor.Namespace); err != nil {
 			return err
 		}
-		// TODO: Modify status
-		return nil
 	}
 
-	// _, err => status, err
-	if _, err := r.reconcileSparkApp(modelMonitor, sparkApp); err != nil {
+	workload, err := r.Backend.Build(monitoringJobName, modelMonitor)
+	if err != nil {
 		return err
 	}
-	// TODO: Modify status
-	return nil
-}
-
-func (r *MonitoringJobReconciler) finalizeSparkApp(monitoringJobName string, namespace string) error {
-	existing := &sparkv1beta2.SparkApplication{}
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: monitoringJobName, Namespace: namespace}, existing); err != nil {
-		if !errors.IsNotFound(err) {
-			return err
-		}
+	if workload == nil {
+		err = r.Backend.Delete(monitoringJobName, modelMonitor.Namespace)
 	} else {
-		r.Log.Info("Deleting Spark Application", "namespace", namespace, "name", monitoringJobName)
-		if err := r.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
-			if !errors.IsNotFound(err) {
-				return err
-			}
-		}
+		workload, err = r.Backend.Reconcile(modelMonitor, workload)
 	}
-	return nil
+	if err != nil {
+		return err
+	}
+	return r.Backend.Status(modelMonitor, workload)
 }
 
-func (r *MonitoringJobReconciler) reconcileSparkApp(modelMonitor *monitoringv1beta1.ModelMonitor, desired *sparkv1beta2.SparkApplication) (*sparkv1beta2.SparkApplicationStatus, error) {
-	// Set ModelMonitor as owner of desired spark app
-	if err := controllerutil.SetControllerReference(modelMonitor, desired, r.Scheme); err != nil {
+// sharedPermissionsModelMonitors returns the ModelMonitors sharing the permissions of the namespace, i.e those not
+// being deleted, with the given ModelMonitor in its current state
+func sharedPermissionsModelMonitors(c client.Client, modelMonitor *monitoringv1beta1.ModelMonitor) ([]monitoringv1beta1.ModelMonitor, error) {
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := c.List(context.TODO(), modelMonitors, client.InNamespace(modelMonitor.Namespace)); err != nil {
 		return nil, err
 	}
-
-	// Create spark app if does not exist
-	existing := &sparkv1beta2.SparkApplication{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// Create service account, role and role binding if do not exist
-			sa, role, roleBinding, err := r.Builder.Permissions.CreateServiceAccountRoleAndBinding(modelMonitor)
-			if err = r.reconcileSparkAppPermissions(sa, role, roleBinding); err != nil {
-				return &desired.Status, err
-			}
-
-			r.Log.Info("Creating Spark Application", "namespace", desired.Namespace, "name", desired.Name)
-			return &desired.Status, r.Client.Create(context.TODO(), desired)
+	shared := []monitoringv1beta1.ModelMonitor{*modelMonitor}
+	for _, mm := range modelMonitors.Items {
+		if mm.Name != modelMonitor.Name && mm.ObjectMeta.DeletionTimestamp.IsZero() {
+			shared = append(shared, mm)
 		}
-		return nil, err
 	}
+	return shared, nil
+}
 
-	// Return if no differences to reconcile.
-	if sparkAppsSemanticEquals(desired, existing) {
-		r.Log.Info("No differences found")
-		return &existing.Status, nil
+// reconcileSharedPermissions creates or updates the permissions of an assignee shared by the ModelMonitors of the
+// namespace
+func reconcileSharedPermissions(c client.Client, log logr.Logger, builder *resources.PermissionsBuilder, modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	modelMonitors, err := sharedPermissionsModelMonitors(c, modelMonitor)
+	if err != nil {
+		return err
 	}
-
-	r.Log.Info("Updating Spark Application", "namespace", desired.Namespace, "name", desired.Name)
-	existing.Spec = desired.Spec
-	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
-	if err := r.Client.Update(context.TODO(), existing); err != nil {
-		return &existing.Status, err
+	sa, role, roleBinding, err := builder.CreateServiceAccountRoleAndBinding(modelMonitor, modelMonitors)
+	if err != nil {
+		return err
 	}
-
-	return &existing.Status, nil
+	return reconcilePermissions(c, log, sa, role, roleBinding)
 }
 
-func (r *MonitoringJobReconciler) reconcileSparkAppPermissions(desiredServiceAccount *corev1.ServiceAccount, desiredRole *rbacv1.Role, desiredRoleBinding *rbacv1.RoleBinding) error {
+// reconcilePermissions creates the service account, role and role binding of an assignee if they do not exist,
+// updating the role rules. Existing objects not managed by the operator are left untouched.
+func reconcilePermissions(c client.Client, log logr.Logger, desiredServiceAccount *corev1.ServiceAccount, desiredRole *rbacv1.Role, desiredRoleBinding *rbacv1.RoleBinding) error {
 	namespace := desiredServiceAccount.Namespace
 
 	// Names
-	serviceAccountName := constants.DefaultServiceAccountName(constants.MonitoringJobAssignee)
-	roleName := constants.DefaultRoleName(constants.MonitoringJobAssignee)
-	roleBindingName := constants.DefaultRoleBindingName(constants.MonitoringJobAssignee)
+	serviceAccountName := desiredServiceAccount.Name
+	roleName := desiredRole.Name
+	roleBindingName := desiredRoleBinding.Name
 
 	// Create service account if does not exist
 	existingSA := &corev1.ServiceAccount{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, existingSA)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, existingSA)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Log.Info("Creating Service Account", "namespace", namespace, "name", serviceAccountName)
-			if err = r.Client.Create(context.TODO(), desiredServiceAccount); err != nil {
+			log.Info("Creating Service Account", "namespace", namespace, "name", serviceAccountName)
+			if err = c.Create(context.TODO(), desiredServiceAccount); err != nil {
 				return err
 			}
 		} else {
@@ -154,24 +132,33 @@ func (r *MonitoringJobReconciler) reconcileSparkAppPermissions(desiredServiceAcc
 	}
 	// Create role if does not exist
 	existingR := &rbacv1.Role{}
-	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: namespace}, existingR)
+	err = c.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: namespace}, existingR)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Log.Info("Creating Role", "namespace", namespace, "name", roleName)
-			if err = r.Client.Create(context.TODO(), desiredRole); err != nil {
+			log.Info("Creating Role", "namespace", namespace, "name", roleName)
+			if err = c.Create(context.TODO(), desiredRole); err != nil {
 				return err
 			}
 		} else {
 			return err
 		}
+	} else if !isManagedPermission(existingR) {
+		log.Info("Role not managed by the operator, rules not updated", "namespace", namespace, "name", roleName)
+	} else if !equality.Semantic.DeepEqual(desiredRole.Rules, existingR.Rules) {
+		// Roles created by previous versions lack newer rules (e.g baseline ConfigMaps)
+		log.Info("Updating Role", "namespace", namespace, "name", roleName)
+		existingR.Rules = desiredRole.Rules
+		if err = c.Update(context.TODO(), existingR); err != nil {
+			return err
+		}
 	}
 	// Create role binding if does not exist
 	existingRB := &rbacv1.RoleBinding{}
-	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: roleBindingName, Namespace: namespace}, existingRB)
+	err = c.Get(context.TODO(), types.NamespacedName{Name: roleBindingName, Namespace: namespace}, existingRB)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Log.Info("Creating Role Binding", "namespace", namespace, "name", roleBindingName)
-			if err = r.Client.Create(context.TODO(), desiredRoleBinding); err != nil {
+			log.Info("Creating Role Binding", "namespace", namespace, "name", roleBindingName)
+			if err = c.Create(context.TODO(), desiredRoleBinding); err != nil {
 				return err
 			}
 		} else {
@@ -181,7 +168,59 @@ func (r *MonitoringJobReconciler) reconcileSparkAppPermissions(desiredServiceAcc
 	return nil
 }
 
-func sparkAppsSemanticEquals(desired *sparkv1beta2.SparkApplication, sparkApp *sparkv1beta2.SparkApplication) bool {
-	return equality.Semantic.DeepEqual(desired.Spec, sparkApp.Spec) &&
-		equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, sparkApp.ObjectMeta.Labels)
+// Finalize deletes the Spark Application permissions when no other ModelMonitor in the namespace uses them
+func (r *MonitoringJobReconciler) Finalize(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	namespace := modelMonitor.Namespace
+
+	modelMonitors := &monitoringv1beta1.ModelMonitorList{}
+	if err := r.Client.List(context.TODO(), modelMonitors, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for _, mm := range modelMonitors.Items {
+		if mm.Name != modelMonitor.Name && mm.ObjectMeta.DeletionTimestamp.IsZero() {
+			return nil
+		}
+	}
+
+	r.Log.Info("Deleting Spark Application permissions", "namespace", namespace)
+	return finalizePermissions(r.Client, r.Log, constants.MonitoringJobAssignee, namespace)
+}
+
+// finalizePermissions deletes the service account, role and role binding of an assignee, if managed by the operator
+func finalizePermissions(c client.Client, log logr.Logger, assignee string, namespace string) error {
+	// Names
+	serviceAccountName := constants.DefaultServiceAccountName(assignee)
+	roleName := constants.DefaultRoleName(assignee)
+	roleBindingName := constants.DefaultRoleBindingName(assignee)
+
+	objects := []runtime.Object{
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleBindingName, Namespace: namespace}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: roleName, Namespace: namespace}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: serviceAccountName, Namespace: namespace}},
+	}
+	for _, obj := range objects {
+		key, err := client.ObjectKeyFromObject(obj)
+		if err != nil {
+			return err
+		}
+		if err = c.Get(context.TODO(), key, obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !isManagedPermission(obj.(metav1.Object)) {
+			log.Info("Permissions not managed by the operator, not deleted", "namespace", namespace, "name", key.Name)
+			continue
+		}
+		if err = c.Delete(context.TODO(), obj); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isManagedPermission returns whether a service account, role or role binding was created by the operator
+func isManagedPermission(obj metav1.Object) bool {
+	return obj.GetLabels()[constants.ManagedByLabelKey] == constants.ControllerLabelName
 }
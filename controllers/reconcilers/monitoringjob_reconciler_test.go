@@ -0,0 +1,216 @@
+package reconcilers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+func newTestMonitoringJobReconciler(objects ...runtime.Object) (*MonitoringJobReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = sparkv1beta2.AddToScheme(scheme)
+	_ = monitoringv1beta1.AddToScheme(scheme)
+	c := fake.NewFakeClientWithScheme(scheme, objects...)
+	config := &monitoringv1beta1.ModelMonitorConfig{Job: &monitoringv1beta1.JobConfig{ContainerImage: "job:latest"}}
+	return NewMonitoringJobReconciler(c, scheme, ctrl.Log, record.NewFakeRecorder(10), config), c
+}
+
+func TestMonitoringJobReconcilerSchedulesJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Monitoring.Trigger.Window = &monitoringv1beta1.WindowSpec{Duration: monitoringv1beta1.Duration{Duration: 10 * time.Second}}
+	r, c := newTestMonitoringJobReconciler()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	// Switching to a schedule replaces the streaming job
+	mm.Spec.Monitoring.Trigger = monitoringv1beta1.TriggerSpec{Schedule: &monitoringv1beta1.ScheduleSpec{Cron: "@hourly", Lookback: monitoringv1beta1.Duration{Duration: time.Hour}}}
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	name := types.NamespacedName{Name: constants.DefaultMonitoringJobName(mm.Name), Namespace: mm.Namespace}
+	g.Expect(c.Get(context.TODO(), name, &sparkv1beta2.SparkApplication{})).NotTo(gomega.Succeed())
+	scheduledSparkApp := &sparkv1beta2.ScheduledSparkApplication{}
+	g.Expect(c.Get(context.TODO(), name, scheduledSparkApp)).To(gomega.Succeed())
+	g.Expect(scheduledSparkApp.Spec.Schedule).To(gomega.Equal("@hourly"))
+	g.Expect(scheduledSparkApp.Spec.ConcurrencyPolicy).To(gomega.Equal(sparkv1beta2.ConcurrencyForbid))
+	g.Expect(scheduledSparkApp.Spec.Template.Driver.EnvVars[constants.MonitoringJobEnvVarMonitoringConfigLabel]).To(
+		gomega.ContainSubstring(`"schedule":{"cron":"@hourly","lookback":3600000}`))
+
+	// Last run recorded in status
+	scheduledSparkApp.Status = sparkv1beta2.ScheduledSparkApplicationStatus{
+		ScheduleState: sparkv1beta2.ScheduledState,
+		LastRun:       metav1.Now(),
+		LastRunName:   "test-monitoring-job-1",
+	}
+	g.Expect(c.Update(context.TODO(), scheduledSparkApp)).To(gomega.Succeed())
+	run := &sparkv1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: "test-monitoring-job-1", Namespace: mm.Namespace}}
+	run.Status.AppState.State = sparkv1beta2.FailedState
+	g.Expect(c.Create(context.TODO(), run)).To(gomega.Succeed())
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	schedule := mm.Status.MonitoringJob.Schedule
+	g.Expect(schedule.LastRunName).To(gomega.Equal("test-monitoring-job-1"))
+	g.Expect(schedule.LastRunResult).To(gomega.Equal(string(sparkv1beta2.FailedState)))
+	g.Expect(schedule.LastRun).NotTo(gomega.BeNil())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.MonitoringJobReady).Reason).To(gomega.Equal("LastRunFailed"))
+}
+
+func TestMonitoringJobReconcilerConvertsWindows(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Monitoring.Trigger = monitoringv1beta1.TriggerSpec{
+		Window:     &monitoringv1beta1.WindowSpec{Duration: monitoringv1beta1.Duration{Duration: 10 * time.Second}, WatermarkDelay: monitoringv1beta1.Duration{Duration: 4 * time.Second}},
+		MinSamples: 100,
+	}
+	r, c := newTestMonitoringJobReconciler()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	sparkApp := &sparkv1beta2.SparkApplication{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultMonitoringJobName(mm.Name), Namespace: mm.Namespace}, sparkApp)).To(gomega.Succeed())
+	// Tumbling windows slide by their length
+	g.Expect(sparkApp.Spec.Driver.EnvVars[constants.MonitoringJobEnvVarMonitoringConfigLabel]).To(
+		gomega.ContainSubstring(`"trigger":{"window":{"duration":10000,"slide":10000,"watermarkDelay":4000},"minSamples":100}`))
+}
+
+func TestMonitoringJobReconcilerRestartsFailedJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	retries := int32(1)
+	mm.Spec.Job.RestartPolicy = &monitoringv1beta1.RestartPolicySpec{Type: monitoringv1beta1.OnFailureRestartPolicy, Retries: &retries}
+	r, c := newTestMonitoringJobReconciler()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	name := types.NamespacedName{Name: constants.DefaultMonitoringJobName(mm.Name), Namespace: mm.Namespace}
+	fail := func() {
+		sparkApp := &sparkv1beta2.SparkApplication{}
+		g.Expect(c.Get(context.TODO(), name, sparkApp)).To(gomega.Succeed())
+		sparkApp.Status.AppState.State = sparkv1beta2.FailedState
+		g.Expect(c.Update(context.TODO(), sparkApp)).To(gomega.Succeed())
+	}
+
+	// Restart scheduled after the backoff, then the app is deleted and created again
+	fail()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.MonitoringJob.NextRestart).NotTo(gomega.BeNil())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.MonitoringJobReady).Reason).To(gomega.Equal("Restarting"))
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), name, &sparkv1beta2.SparkApplication{})).NotTo(gomega.Succeed())
+	g.Expect(mm.Status.MonitoringJob.Restarts).To(gomega.Equal(int32(1)))
+	g.Expect(mm.Status.MonitoringJob.LastRestart).NotTo(gomega.BeNil())
+	g.Expect(mm.Status.MonitoringJob.NextRestart).To(gomega.BeNil())
+
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), name, &sparkv1beta2.SparkApplication{})).To(gomega.Succeed())
+
+	// Not restarted once the retries are exhausted
+	fail()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.MonitoringJob.NextRestart).To(gomega.BeNil())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.MonitoringJobReady).Reason).To(gomega.Equal(string(sparkv1beta2.FailedState)))
+	g.Expect(c.Get(context.TODO(), name, &sparkv1beta2.SparkApplication{})).To(gomega.Succeed())
+}
+
+func TestMonitoringJobReconcilerDeploymentBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Job.Driver.Memory = "1g"
+	r, c := newTestMonitoringJobReconciler()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	// Switching backends replaces the Spark Application
+	config := &monitoringv1beta1.ModelMonitorConfig{Job: &monitoringv1beta1.JobConfig{Backend: monitoringv1beta1.DeploymentJobBackend, ContainerImage: "job-lite:latest"}}
+	r = NewMonitoringJobReconciler(c, r.Scheme, ctrl.Log, record.NewFakeRecorder(10), config)
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	name := types.NamespacedName{Name: constants.DefaultMonitoringJobName(mm.Name), Namespace: mm.Namespace}
+	g.Expect(c.Get(context.TODO(), name, &sparkv1beta2.SparkApplication{})).NotTo(gomega.Succeed())
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(context.TODO(), name, deployment)).To(gomega.Succeed())
+	container := deployment.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Image).To(gomega.Equal("job-lite:latest"))
+	g.Expect(container.Resources.Limits.Memory().String()).To(gomega.Equal("1Gi"))
+	env := map[string]string{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	g.Expect(env).To(gomega.HaveKey(constants.MonitoringJobEnvVarMonitoringConfigLabel))
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.MonitoringJobReady).Reason).To(gomega.Equal("MonitoringJobUnavailable"))
+
+	// Running once the replica is available
+	deployment.Status.AvailableReplicas = 1
+	g.Expect(c.Update(context.TODO(), deployment)).To(gomega.Succeed())
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.MonitoringJobReady)).To(gomega.BeTrue())
+	g.Expect(mm.Status.MonitoringJob.State).To(gomega.Equal(string(sparkv1beta2.RunningState)))
+
+	// Schedules are not supported
+	mm.Spec.Monitoring.Trigger = monitoringv1beta1.TriggerSpec{Schedule: &monitoringv1beta1.ScheduleSpec{Cron: "@hourly", Lookback: monitoringv1beta1.Duration{Duration: time.Hour}}}
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), name, &appsv1.Deployment{})).NotTo(gomega.Succeed())
+	g.Expect(mm.Status.GetCondition(monitoringv1beta1.MonitoringJobReady).Reason).To(gomega.Equal("ScheduleNotSupported"))
+}
+
+func TestMonitoringJobReconcilerMountsJobVolumes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Job.Volumes = []corev1.Volume{{
+		Name:         "checkpoints",
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "test-checkpoints"}},
+	}}
+	mm.Spec.Job.VolumeMounts = []corev1.VolumeMount{{Name: "checkpoints", MountPath: "/checkpoints"}}
+	mm.Spec.Job.CheckpointLocation = "/checkpoints/test"
+	r, c := newTestMonitoringJobReconciler()
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	sparkApp := &sparkv1beta2.SparkApplication{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultMonitoringJobName(mm.Name), Namespace: mm.Namespace}, sparkApp)).To(gomega.Succeed())
+	g.Expect(sparkApp.Spec.Volumes).To(gomega.Equal(mm.Spec.Job.Volumes))
+	for _, volume := range sparkApp.Spec.Volumes {
+		g.Expect(volume.HostPath).To(gomega.BeNil())
+	}
+	g.Expect(sparkApp.Spec.Driver.VolumeMounts).To(gomega.Equal(mm.Spec.Job.VolumeMounts))
+	g.Expect(sparkApp.Spec.Executor.VolumeMounts).To(gomega.Equal(mm.Spec.Job.VolumeMounts))
+	g.Expect(sparkApp.Spec.Driver.EnvVars[constants.MonitoringJobEnvVarCheckpointLocationLabel]).To(gomega.Equal("/checkpoints/test"))
+}
+
+func TestMonitoringJobReconcilerFinalizeManagedPermissions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	mm := newTestModelMonitor()
+	mm.Spec.Monitoring.Trigger.Window = &monitoringv1beta1.WindowSpec{Duration: monitoringv1beta1.Duration{Duration: 10 * time.Second}}
+	serviceAccountName := constants.DefaultServiceAccountName(constants.MonitoringJobAssignee)
+	userServiceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: serviceAccountName, Namespace: mm.Namespace}}
+	r, c := newTestMonitoringJobReconciler(mm.DeepCopy(), userServiceAccount)
+	g.Expect(r.Reconcile(mm)).To(gomega.Succeed())
+
+	roleName := types.NamespacedName{Name: constants.DefaultRoleName(constants.MonitoringJobAssignee), Namespace: mm.Namespace}
+	role := &rbacv1.Role{}
+	g.Expect(c.Get(context.TODO(), roleName, role)).To(gomega.Succeed())
+	g.Expect(role.Labels).To(gomega.HaveKeyWithValue(constants.ManagedByLabelKey, constants.ControllerLabelName))
+
+	// Only the permissions created by the operator are deleted
+	g.Expect(r.Finalize(mm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), roleName, role)).NotTo(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: serviceAccountName, Namespace: mm.Namespace}, &corev1.ServiceAccount{})).To(gomega.Succeed())
+}
@@ -0,0 +1,307 @@
+package reconcilers
+
+import (
+	"context"
+	"time"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/controllers/resources"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/client-go/tools/record"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+// SparkJobBackend runs the Monitoring job as a streaming Spark Application, or as a Scheduled Spark Application if
+// the job is scheduled. Requires the Spark operator.
+type SparkJobBackend struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Builder  *resources.MonitoringJobBuilder
+}
+
+// Build builds the Scheduled Spark Application of the job if scheduled, or its streaming Spark Application
+func (b *SparkJobBackend) Build(name string, modelMonitor *monitoringv1beta1.ModelMonitor) (runtime.Object, error) {
+	scheduledSparkApp, err := b.Builder.CreateMonitoringJobScheduledSparkApp(name, modelMonitor)
+	if err != nil || scheduledSparkApp != nil {
+		return scheduledSparkApp, err
+	}
+	sparkApp, err := b.Builder.CreateMonitoringJobSparkApp(name, modelMonitor)
+	if err != nil || sparkApp == nil {
+		return nil, err
+	}
+	return sparkApp, nil
+}
+
+// Reconcile creates or updates the Spark Application or Scheduled Spark Application of the job, replacing the other
+// if the trigger changed
+func (b *SparkJobBackend) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor, desired runtime.Object) (runtime.Object, error) {
+	switch desired := desired.(type) {
+	case *sparkv1beta2.ScheduledSparkApplication:
+		if err := b.finalizeSparkApp(desired.Name, desired.Namespace); err != nil {
+			return nil, err
+		}
+		return b.reconcileScheduledSparkApp(modelMonitor, desired)
+	case *sparkv1beta2.SparkApplication:
+		if err := b.finalizeScheduledSparkApp(desired.Name, desired.Namespace); err != nil {
+			return nil, err
+		}
+		existing, updated, err := b.reconcileSparkApp(modelMonitor, desired)
+		if updated {
+			// Resubmitted by the Spark operator on spec changes, restarts start over
+			modelMonitor.Status.MonitoringJob.Restarts = 0
+			modelMonitor.Status.MonitoringJob.NextRestart = nil
+		}
+		return existing, err
+	}
+	return nil, nil
+}
+
+// Status propagates the status of the Spark Application, restarting it according to the restart policy, or the result
+// of the last run of the Scheduled Spark Application
+func (b *SparkJobBackend) Status(modelMonitor *monitoringv1beta1.ModelMonitor, workload runtime.Object) error {
+	switch workload := workload.(type) {
+	case *sparkv1beta2.ScheduledSparkApplication:
+		return b.reconcileSchedule(modelMonitor, &workload.Status)
+	case *sparkv1beta2.SparkApplication:
+		status, err := b.reconcileRestart(modelMonitor, &workload.Status)
+		if err != nil {
+			return err
+		}
+		modelMonitor.Status.PropagateMonitoringJobStatus(status)
+		modelMonitor.Status.MonitoringJob.ModelRevision = modelMonitor.JobModelRevision()
+		return nil
+	}
+	modelMonitor.Status.PropagateMonitoringJobStatus(nil)
+	return nil
+}
+
+// Delete deletes the Spark Application and Scheduled Spark Application of the job
+func (b *SparkJobBackend) Delete(name string, namespace string) error {
+	if err := b.finalizeSparkApp(name, namespace); err != nil {
+		return err
+	}
+	return b.finalizeScheduledSparkApp(name, namespace)
+}
+
+// reconcileSchedule propagates the result of the last run of the Scheduled Spark Application
+func (b *SparkJobBackend) reconcileSchedule(modelMonitor *monitoringv1beta1.ModelMonitor, status *sparkv1beta2.ScheduledSparkApplicationStatus) error {
+	// Runs are owned by the Scheduled Spark Application and garbage collected beyond the history limit
+	var lastRun *sparkv1beta2.SparkApplicationStatus
+	if status.LastRunName != "" {
+		run := &sparkv1beta2.SparkApplication{}
+		err := b.Client.Get(context.TODO(), types.NamespacedName{Name: status.LastRunName, Namespace: modelMonitor.Namespace}, run)
+		if err == nil {
+			lastRun = &run.Status
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	previous := modelMonitor.Status.MonitoringJob.Schedule.DeepCopy()
+	modelMonitor.Status.PropagateScheduledMonitoringJobStatus(status, lastRun)
+	modelMonitor.Status.MonitoringJob.ModelRevision = modelMonitor.JobModelRevision()
+
+	if current := modelMonitor.Status.MonitoringJob.Schedule; current.Finished() && (previous == nil ||
+		previous.LastRunName != current.LastRunName || previous.LastRunResult != current.LastRunResult) {
+		eventType := corev1.EventTypeNormal
+		if current.LastRunResult != string(sparkv1beta2.CompletedState) {
+			eventType = corev1.EventTypeWarning
+		}
+		b.Recorder.Eventf(modelMonitor, eventType, "MonitoringRunFinished", "Run %s finished: %s", current.LastRunName, current.LastRunResult)
+	}
+	return nil
+}
+
+func (b *SparkJobBackend) reconcileScheduledSparkApp(modelMonitor *monitoringv1beta1.ModelMonitor, desired *sparkv1beta2.ScheduledSparkApplication) (*sparkv1beta2.ScheduledSparkApplication, error) {
+	// Set ModelMonitor as owner of desired scheduled spark app
+	if err := controllerutil.SetControllerReference(modelMonitor, desired, b.Scheme); err != nil {
+		return nil, err
+	}
+
+	// Create scheduled spark app if does not exist
+	existing := &sparkv1beta2.ScheduledSparkApplication{}
+	err := b.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Create service account, role and role binding if do not exist
+			if err := reconcileSharedPermissions(b.Client, b.Log, b.Builder.Permissions, modelMonitor); err != nil {
+				return desired, err
+			}
+
+			b.Log.Info("Creating Scheduled Spark Application", "namespace", desired.Namespace, "name", desired.Name)
+			return desired, b.Client.Create(context.TODO(), desired)
+		}
+		return nil, err
+	}
+
+	// Return if no differences to reconcile.
+	if equality.Semantic.DeepEqual(desired.Spec, existing.Spec) && equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, existing.ObjectMeta.Labels) {
+		b.Log.Info("No differences found")
+		return existing, nil
+	}
+
+	// Applied from the next run
+	b.Log.Info("Updating Scheduled Spark Application", "namespace", desired.Namespace, "name", desired.Name)
+	existing.Spec = desired.Spec
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	if err := b.Client.Update(context.TODO(), existing); err != nil {
+		return existing, err
+	}
+	return existing, nil
+}
+
+func (b *SparkJobBackend) finalizeScheduledSparkApp(monitoringJobName string, namespace string) error {
+	existing := &sparkv1beta2.ScheduledSparkApplication{}
+	if err := b.Client.Get(context.TODO(), types.NamespacedName{Name: monitoringJobName, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return ignoreNoMatch(err)
+		}
+	} else {
+		b.Log.Info("Deleting Scheduled Spark Application", "namespace", namespace, "name", monitoringJobName)
+		if err := b.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *SparkJobBackend) finalizeSparkApp(monitoringJobName string, namespace string) error {
+	existing := &sparkv1beta2.SparkApplication{}
+	if err := b.Client.Get(context.TODO(), types.NamespacedName{Name: monitoringJobName, Namespace: namespace}, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return ignoreNoMatch(err)
+		}
+	} else {
+		b.Log.Info("Deleting Spark Application", "namespace", namespace, "name", monitoringJobName)
+		if err := b.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileSparkApp creates or updates a Spark Application, returning the existing one and whether it was updated
+func (b *SparkJobBackend) reconcileSparkApp(modelMonitor *monitoringv1beta1.ModelMonitor, desired *sparkv1beta2.SparkApplication) (*sparkv1beta2.SparkApplication, bool, error) {
+	// Set ModelMonitor as owner of desired spark app
+	if err := controllerutil.SetControllerReference(modelMonitor, desired, b.Scheme); err != nil {
+		return nil, false, err
+	}
+
+	// Create spark app if does not exist
+	existing := &sparkv1beta2.SparkApplication{}
+	err := b.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Create service account, role and role binding if do not exist
+			if err := reconcileSharedPermissions(b.Client, b.Log, b.Builder.Permissions, modelMonitor); err != nil {
+				return desired, false, err
+			}
+
+			b.Log.Info("Creating Spark Application", "namespace", desired.Namespace, "name", desired.Name)
+			return desired, false, b.Client.Create(context.TODO(), desired)
+		}
+		return nil, false, err
+	}
+
+	// Wait for the driver to be deleted before creating it again
+	if !existing.ObjectMeta.DeletionTimestamp.IsZero() {
+		b.Log.Info("Waiting for Spark Application deletion", "namespace", existing.Namespace, "name", existing.Name)
+		existing.Status = sparkv1beta2.SparkApplicationStatus{}
+		return existing, false, nil
+	}
+
+	// Return if no differences to reconcile.
+	if sparkAppsSemanticEquals(desired, existing) {
+		b.Log.Info("No differences found")
+		return existing, false, nil
+	}
+
+	b.Log.Info("Updating Spark Application", "namespace", desired.Namespace, "name", desired.Name)
+	existing.Spec = desired.Spec
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	if err := b.Client.Update(context.TODO(), existing); err != nil {
+		return existing, false, err
+	}
+
+	// Resubmitted by the Spark operator on spec changes, the previous state no longer applies
+	existing.Status = sparkv1beta2.SparkApplicationStatus{}
+	return existing, true, nil
+}
+
+// reconcileRestart restarts the terminated Spark Application of the streaming job according to the restart policy,
+// once the backoff elapses. The application is deleted along with its driver, and created again on the next reconcile.
+func (b *SparkJobBackend) reconcileRestart(modelMonitor *monitoringv1beta1.ModelMonitor, appStatus *sparkv1beta2.SparkApplicationStatus) (*sparkv1beta2.SparkApplicationStatus, error) {
+	policy := modelMonitor.Spec.Job.RestartPolicy
+	jobStatus := &modelMonitor.Status.MonitoringJob
+	state := appStatus.AppState.State
+	if policy == nil || !policy.RestartsOn(state) {
+		jobStatus.NextRestart = nil
+		return appStatus, nil
+	}
+
+	eventType := corev1.EventTypeWarning
+	if state == sparkv1beta2.CompletedState {
+		eventType = corev1.EventTypeNormal
+	}
+
+	// Schedule the restart
+	if jobStatus.NextRestart == nil {
+		// Jobs failing after running for a while are not crash looping, restarts start over
+		if ranFor := appStatus.TerminationTime.Sub(appStatus.LastSubmissionAttemptTime.Time); ranFor >= constants.MonitoringJobRestartResetInterval {
+			jobStatus.Restarts = 0
+		}
+		if policy.Retries != nil && jobStatus.Restarts >= *policy.Retries {
+			if jobStatus.State != string(state) {
+				b.Recorder.Eventf(modelMonitor, eventType, "MonitoringJobTerminated", "Spark Application %s, not restarted after %d restarts", state, jobStatus.Restarts)
+			}
+			return appStatus, nil
+		}
+		delay := policy.Delay(jobStatus.Restarts)
+		nextRestart := metav1.NewTime(time.Now().Add(delay))
+		jobStatus.NextRestart = &nextRestart
+		b.Recorder.Eventf(modelMonitor, eventType, "MonitoringJobTerminated", "Spark Application %s, restarting in %s", state, delay)
+		return appStatus, nil
+	}
+	if time.Now().Before(jobStatus.NextRestart.Time) {
+		return appStatus, nil
+	}
+
+	name := constants.DefaultMonitoringJobName(modelMonitor.Name)
+	existing := &sparkv1beta2.SparkApplication{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: modelMonitor.Namespace}}
+	b.Log.Info("Restarting Spark Application", "namespace", existing.Namespace, "name", existing.Name, "state", state)
+	if err := b.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	lastRestart := metav1.Now()
+	jobStatus.Restarts++
+	jobStatus.LastRestart = &lastRestart
+	jobStatus.NextRestart = nil
+	b.Recorder.Eventf(modelMonitor, corev1.EventTypeNormal, "MonitoringJobRestarted", "Spark Application restarted (%d consecutive restarts)", jobStatus.Restarts)
+	return &sparkv1beta2.SparkApplicationStatus{}, nil
+}
+
+func sparkAppsSemanticEquals(desired *sparkv1beta2.SparkApplication, sparkApp *sparkv1beta2.SparkApplication) bool {
+	return equality.Semantic.DeepEqual(desired.Spec, sparkApp.Spec) &&
+		equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, sparkApp.ObjectMeta.Labels)
+}
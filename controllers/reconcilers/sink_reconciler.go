@@ -0,0 +1,65 @@
+package reconcilers
+
+import (
+	"net/http"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/sinks"
+
+	"github.com/go-logr/logr"
+)
+
+// SinkReconciler defines a reconciler checking the availability of the non-Kafka analysis sinks
+type SinkReconciler struct {
+	Log logr.Logger
+	// HTTPClient is the client used to probe the sinks, nil if probes are disabled
+	HTTPClient *http.Client
+}
+
+// NewSinkReconciler creates a new reconciler for the analysis sinks
+func NewSinkReconciler(log logr.Logger, httpClient *http.Client) *SinkReconciler {
+	return &SinkReconciler{
+		Log:        log,
+		HTTPClient: httpClient,
+	}
+}
+
+// Reconcile probes the S3 and Pushgateway sinks of a given ModelMonitor, if probes are enabled. Unavailable sinks are
+// reported in status. JDBC and webhook sinks are not probed, since it would require database drivers or sending
+// requests with side effects.
+func (r *SinkReconciler) Reconcile(modelMonitor *monitoringv1beta1.ModelMonitor) error {
+	var statuses []monitoringv1beta1.SinkStatus
+	for _, sink := range modelMonitor.Spec.Storage.Analysis.Sinks() {
+		if sink.Kafka != nil {
+			continue
+		}
+
+		status := monitoringv1beta1.SinkStatus{Name: "analysis." + sink.Name}
+		var err error
+		probe := r.HTTPClient != nil
+		switch {
+		case sink.S3 != nil:
+			status.Type = monitoringv1beta1.S3SinkType
+			if probe {
+				err = sinks.ProbeS3Bucket(r.HTTPClient, sink.S3.Endpoint, sink.S3.Region, sink.S3.Bucket, sink.S3.PathStyleAccess)
+			}
+		case sink.Pushgateway != nil:
+			status.Type = monitoringv1beta1.PushgatewaySinkType
+			if probe {
+				err = sinks.ProbePushgateway(r.HTTPClient, sink.Pushgateway.URL)
+			}
+		case sink.JDBC != nil:
+			status.Type = monitoringv1beta1.JDBCSinkType
+		case sink.Webhook != nil:
+			status.Type = monitoringv1beta1.WebhookSinkType
+		}
+		if err != nil {
+			r.Log.Info("Sink unavailable", "sink", status.Name, "type", status.Type, "error", err.Error())
+			status.Error = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+
+	modelMonitor.Status.PropagateSinksStatus(statuses)
+	return nil
+}
@@ -0,0 +1,79 @@
+package reconcilers
+
+import (
+	"net/http"
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/sinks/sinkstest"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestSinkReconcilerProbesSinks(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	objectStore := sinkstest.NewObjectStore("stats")
+	defer objectStore.Close()
+	pushgateway := sinkstest.NewPushgateway()
+	defer pushgateway.Close()
+
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.Analysis.Stats = monitoringv1beta1.SinkSpec{
+		S3: &monitoringv1beta1.S3SinkSpec{Endpoint: objectStore.URL, Bucket: "stats", PathStyleAccess: true},
+	}
+	mm.Spec.Storage.Analysis.Outliers = &monitoringv1beta1.SinkSpec{
+		Pushgateway: &monitoringv1beta1.PushgatewaySinkSpec{URL: pushgateway.URL},
+	}
+	mm.Spec.Storage.Analysis.Drift = &monitoringv1beta1.SinkSpec{
+		Webhook: &monitoringv1beta1.WebhookSinkSpec{URL: "http://localhost/drift"},
+	}
+
+	g.Expect(NewSinkReconciler(ctrl.Log, http.DefaultClient).Reconcile(mm)).To(gomega.Succeed())
+
+	g.Expect(mm.Status.Sinks).To(gomega.Equal([]monitoringv1beta1.SinkStatus{
+		{Name: "analysis.stats", Type: monitoringv1beta1.S3SinkType},
+		{Name: "analysis.outliers", Type: monitoringv1beta1.PushgatewaySinkType},
+		{Name: "analysis.drift", Type: monitoringv1beta1.WebhookSinkType},
+	}))
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.StorageReady)).To(gomega.BeTrue())
+}
+
+func TestSinkReconcilerReportsMissingBucket(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	objectStore := sinkstest.NewObjectStore()
+	defer objectStore.Close()
+
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.Analysis.Stats = monitoringv1beta1.SinkSpec{
+		S3: &monitoringv1beta1.S3SinkSpec{Endpoint: objectStore.URL, Bucket: "stats", PathStyleAccess: true},
+	}
+
+	g.Expect(NewSinkReconciler(ctrl.Log, http.DefaultClient).Reconcile(mm)).To(gomega.Succeed())
+
+	g.Expect(mm.Status.Sinks[0].Error).NotTo(gomega.BeEmpty())
+	condition := mm.Status.GetCondition(monitoringv1beta1.StorageReady)
+	g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal("SinkUnavailable"))
+}
+
+func TestSinkReconcilerProbesDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	objectStore := sinkstest.NewObjectStore()
+	defer objectStore.Close()
+
+	mm := newTestModelMonitor()
+	mm.Spec.Storage.Analysis.Stats = monitoringv1beta1.SinkSpec{
+		S3: &monitoringv1beta1.S3SinkSpec{Endpoint: objectStore.URL, Bucket: "stats", PathStyleAccess: true},
+	}
+
+	// Listed without being requested
+	g.Expect(NewSinkReconciler(ctrl.Log, nil).Reconcile(mm)).To(gomega.Succeed())
+	g.Expect(mm.Status.Sinks).To(gomega.Equal([]monitoringv1beta1.SinkStatus{
+		{Name: "analysis.stats", Type: monitoringv1beta1.S3SinkType},
+	}))
+	g.Expect(mm.Status.IsConditionTrue(monitoringv1beta1.StorageReady)).To(gomega.BeTrue())
+}
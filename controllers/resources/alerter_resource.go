@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"encoding/json"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AlerterBuilder defines the builder for the Alerter
+type AlerterBuilder struct {
+	ModelMonitorConfig *monitoringv1beta1.ModelMonitorConfig
+	Permissions        *PermissionsBuilder
+	Log                logr.Logger
+}
+
+// NewAlerterBuilder creates an Alerter builder
+func NewAlerterBuilder(config *monitoringv1beta1.ModelMonitorConfig, log logr.Logger) *AlerterBuilder {
+	return &AlerterBuilder{
+		ModelMonitorConfig: config,
+		Permissions:        NewPermissionsBuilder(constants.AlerterAssignee, config, log),
+		Log:                log,
+	}
+}
+
+// CreateAlerterDeployment creates the Deployment of the Alerter, nil if no alerting rules are defined
+func (b *AlerterBuilder) CreateAlerterDeployment(name string, modelMonitor *monitoringv1beta1.ModelMonitor) (*appsv1.Deployment, error) {
+	alertingSpec := modelMonitor.Spec.Monitoring.Alerting
+	if alertingSpec == nil {
+		return nil, nil
+	}
+	metadata := modelMonitor.ObjectMeta
+
+	alertingConfig, err := json.Marshal(alertingSpec)
+	if err != nil {
+		return nil, err
+	}
+	sources := map[monitoringv1beta1.AlertSource]*monitoringv1beta1.KafkaSpec{}
+	for _, source := range alertingSpec.Sources() {
+		sources[source] = modelMonitor.Spec.Storage.Analysis.AlertSourceKafka(source)
+	}
+	alertingSources, err := json.Marshal(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	podLabels := map[string]string{constants.ModelMonitorPodLabelKey: name}
+	for key, value := range metadata.Labels {
+		podLabels[key] = value
+	}
+	replicas := constants.AlerterDefaultReplicas
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metadata.Namespace,
+			Labels:    metadata.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{constants.ModelMonitorPodLabelKey: name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: constants.DefaultServiceAccountName(constants.AlerterAssignee),
+					Containers: []corev1.Container{
+						{
+							Name:  constants.AlerterNameSuffix,
+							Image: b.ModelMonitorConfig.Alerter.ContainerImage,
+							Env: []corev1.EnvVar{
+								{Name: constants.AlerterEnvVarModelMonitorNameLabel, Value: metadata.Name},
+								{Name: constants.AlerterEnvVarModelMonitorNamespaceLabel, Value: metadata.Namespace},
+								{Name: constants.AlerterEnvVarModelMonitorUIDLabel, Value: string(metadata.UID)},
+								{Name: constants.AlerterEnvVarAlertingConfigLabel, Value: string(alertingConfig)},
+								{Name: constants.AlerterEnvVarAlertingSourcesLabel, Value: string(alertingSources)},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse(constants.AlerterDefaultCPU),
+									corev1.ResourceMemory: resource.MustParse(constants.AlerterDefaultMemory),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return deployment, nil
+}
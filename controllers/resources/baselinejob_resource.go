@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+// CreateBaselineJobSparkApp creates the Spark Application for the Baseline job, or nil if the baselines are not
+// computed. The Baseline job runs the Monitoring job image with the same config, plus the dataset or the inference
+// traffic to capture and the ConfigMap to write the baselines to.
+func (b *MonitoringJobBuilder) CreateBaselineJobSparkApp(baselineJobName string, modelMonitor *monitoringv1beta1.ModelMonitor) (*sparkv1beta2.SparkApplication, error) {
+	if !modelMonitor.Spec.Monitoring.ComputesBaselines() {
+		return nil, nil
+	}
+	baselineSpec := modelMonitor.Spec.Monitoring.Baseline
+	jobStatus := modelMonitor.Status.BaselineJob
+
+	sparkApp, err := b.buildMonitoringJobSparkApp(baselineJobName, modelMonitor)
+	if err != nil {
+		return nil, err
+	}
+
+	// One-off job, not tied to the serving revisions nor to previous baselines. The job volumes are not mounted, so
+	// that the Baseline job doesn't compete with the Monitoring job for volumes such as the checkpoints claim.
+	sparkApp.Spec.Volumes = nil
+	sparkApp.Spec.Driver.VolumeMounts = nil
+	sparkApp.Spec.Executor.VolumeMounts = nil
+	envVars := sparkApp.Spec.Driver.EnvVars
+	delete(envVars, constants.MonitoringJobEnvVarBaselineRevisionLabel)
+	delete(envVars, constants.MonitoringJobEnvVarRevisionHeaderLabel)
+	delete(envVars, constants.MonitoringJobEnvVarBaselineJobIDLabel)
+	delete(envVars, constants.MonitoringJobEnvVarCheckpointLocationLabel)
+	envVars[constants.BaselineJobEnvVarConfigMapLabel] = constants.DefaultBaselineConfigMapName(modelMonitor.Name)
+	sparkApp.Spec.Monitoring = nil
+
+	if baselineSpec.Dataset != nil {
+		datasetBytes, err := json.Marshal(baselineSpec.Dataset)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to marshal %v object to %v ", baselineSpec.Dataset, err)
+		}
+		envVars[constants.BaselineJobEnvVarDatasetLabel] = string(datasetBytes)
+	}
+	if baselineSpec.Traffic != nil {
+		traffic := baselineSpec.Traffic.DeepCopy()
+		if traffic.Windows > 0 && jobStatus != nil && jobStatus.RebaselinedAt != nil {
+			// Windows captured from the last re-baseline
+			traffic.From = jobStatus.RebaselinedAt.DeepCopy()
+		}
+		trafficBytes, err := json.Marshal(traffic)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to marshal %v object to %v ", traffic, err)
+		}
+		envVars[constants.BaselineJobEnvVarTrafficLabel] = string(trafficBytes)
+	}
+
+	// Re-baseline requests change the spec, so the Spark operator reruns the job
+	if jobStatus != nil && jobStatus.Rebaseline != "" {
+		envVars[constants.BaselineJobEnvVarRebaselineLabel] = jobStatus.Rebaseline
+	}
+
+	return sparkApp, nil
+}
+
+// CreateBaselineConfigMap creates the ConfigMap the Baseline job writes the baselines to, empty until it completes
+func (b *MonitoringJobBuilder) CreateBaselineConfigMap(modelMonitor *monitoringv1beta1.ModelMonitor) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.DefaultBaselineConfigMapName(modelMonitor.Name),
+			Namespace: modelMonitor.Namespace,
+			Labels:    modelMonitor.Labels,
+		},
+	}
+}
@@ -1,8 +1,8 @@
 package resources
 
 import (
-	"fmt"
 	"strconv"
+	"strings"
 
 	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
 	"github.com/javierdlrm/model-monitoring-operator/constants"
@@ -13,7 +13,6 @@ import (
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -41,14 +40,9 @@ type InferenceLoggerBuilder struct {
 }
 
 // NewInferenceLoggerBuilder creates an InferenceLogger builder
-func NewInferenceLoggerBuilder(config *corev1.ConfigMap, log logr.Logger) *InferenceLoggerBuilder {
-	modelMonitorConfig, err := monitoringv1beta1.NewModelMonitorConfig(config)
-	if err != nil {
-		fmt.Printf("Failed to get model monitor config %s", err.Error())
-		panic("Failed to get model monitor config")
-	}
+func NewInferenceLoggerBuilder(config *monitoringv1beta1.ModelMonitorConfig, log logr.Logger) *InferenceLoggerBuilder {
 	return &InferenceLoggerBuilder{
-		ModelMonitorConfig: modelMonitorConfig,
+		ModelMonitorConfig: config,
 		Log:                log,
 	}
 }
@@ -67,11 +61,8 @@ func (b *InferenceLoggerBuilder) CreateInferenceLoggerService(serviceName string
 		return nil, err
 	}
 
-	// Resources
-	resources, err := b.buildResources(metadata, inferenceLoggerSpec)
-	if err != nil {
-		return nil, err
-	}
+	// Kafka credentials, mounted from Secrets
+	volumes, volumeMounts := buildSecretVolumes(inferenceSpec.Kafka.SecretNames())
 
 	// Concurrency (scaling hard limit, default 0 means limitless)
 	concurrency := int64(inferenceLoggerSpec.Target)
@@ -101,7 +92,15 @@ func (b *InferenceLoggerBuilder) CreateInferenceLoggerService(serviceName string
 									Image:           b.ModelMonitorConfig.InferenceLogger.ContainerImage,
 									Name:            constants.ModelMonitorContainerName,
 									ImagePullPolicy: corev1.PullAlways,
-									Env: []corev1.EnvVar{
+									Env: append([]corev1.EnvVar{
+										corev1.EnvVar{
+											Name:  constants.InferenceLoggerEnvInferenceProtocolLabel,
+											Value: string(modelMonitor.Spec.Model.Protocol),
+										},
+										corev1.EnvVar{
+											Name:  constants.InferenceLoggerEnvMetadataHeadersLabel,
+											Value: strings.Join(constants.InferenceLoggerMetadataHeaders, ","),
+										},
 										corev1.EnvVar{
 											Name:  constants.InferenceLoggerEnvKafkaBrokersLabel,
 											Value: inferenceSpec.Kafka.Brokers,
@@ -118,7 +117,8 @@ func (b *InferenceLoggerBuilder) CreateInferenceLoggerService(serviceName string
 											Name:  constants.InferenceLoggerEnvKafkaTopicReplicationFactorLabel,
 											Value: typesutils.String16(inferenceSpec.Kafka.Topic.ReplicationFactor),
 										},
-									},
+									}, buildKafkaSecurityEnvVars(&inferenceSpec.Kafka)...),
+									VolumeMounts: volumeMounts,
 									ReadinessProbe: &corev1.Probe{
 										Handler: corev1.Handler{
 											TCPSocket: &corev1.TCPSocketAction{
@@ -127,9 +127,10 @@ func (b *InferenceLoggerBuilder) CreateInferenceLoggerService(serviceName string
 										},
 										SuccessThreshold: 1,
 									},
-									Resources: resources,
+									Resources: inferenceLoggerSpec.Resources,
 								},
 							},
+							Volumes: volumes,
 						},
 					},
 				},
@@ -146,98 +147,16 @@ func (b *InferenceLoggerBuilder) buildAnnotations(metadata metav1.ObjectMeta, sp
 		return !utils.Includes(customizableServiceAnnotations, key)
 	})
 
-	// Autoscaler
-	if spec.Autoscaler == "" {
-		annotations[autoscaling.ClassAnnotationKey] = constants.InferenceLoggerDefaultScalingClass
-	} else {
-		annotations[autoscaling.ClassAnnotationKey] = string(spec.Autoscaler)
-	}
-
-	// Metric
-	if spec.Metric == "" {
-		annotations[autoscaling.MetricAnnotationKey] = constants.InferenceLoggerDefaultScalingMetric
-	} else {
-		annotations[autoscaling.MetricAnnotationKey] = string(spec.Metric)
-	}
-
-	// Target
-	if spec.Target == 0 {
-		annotations[autoscaling.TargetAnnotationKey] = fmt.Sprint(constants.InferenceLoggerDefaultScalingTarget)
-	} else {
-		annotations[autoscaling.TargetAnnotationKey] = strconv.Itoa(spec.Target)
-	}
-
-	// Target utilization
-	if spec.TargetUtilization == "" {
-		annotations[autoscaling.TargetUtilizationPercentageKey] = constants.InferenceLoggerDefaultTargetUtilizationPercentage
-	} else {
-		annotations[autoscaling.TargetUtilizationPercentageKey] = spec.TargetUtilization
-	}
-
-	// Window
-	if spec.Window == "" {
-		annotations[autoscaling.WindowAnnotationKey] = constants.InferenceLoggerDefaultWindow
-	} else {
-		annotations[autoscaling.WindowAnnotationKey] = spec.Window
-	}
-
-	// Panic window
-	if spec.PanicWindow == "" {
-		annotations[autoscaling.PanicWindowPercentageAnnotationKey] = constants.InferenceLoggerDefaultPanicWindow
-	} else {
-		annotations[autoscaling.PanicWindowPercentageAnnotationKey] = spec.PanicWindow
-	}
-
-	// Panic threshold
-	if spec.PanicThreshold == "" {
-		annotations[autoscaling.PanicThresholdPercentageAnnotationKey] = constants.InferenceLoggerDefaultPanicThreshold
-	} else {
-		annotations[autoscaling.PanicThresholdPercentageAnnotationKey] = spec.PanicThreshold
-	}
-
-	// Min replicas
-	if spec.MinScale == 0 {
-		annotations[autoscaling.MinScaleAnnotationKey] = fmt.Sprint(constants.InferenceLoggerDefaultMinScale)
-	} else {
-		annotations[autoscaling.MinScaleAnnotationKey] = strconv.Itoa(spec.MinScale)
-	}
-
-	// Max replicas
-	if spec.MaxScale == 0 {
-		annotations[autoscaling.MaxScaleAnnotationKey] = fmt.Sprint(constants.InferenceLoggerDefaultMaxScale)
-	} else {
-		annotations[autoscaling.MaxScaleAnnotationKey] = strconv.Itoa(spec.MaxScale)
-	}
+	// Defaults are applied to the spec by the ModelMonitor webhook and reconciler
+	annotations[autoscaling.ClassAnnotationKey] = string(spec.Autoscaler)
+	annotations[autoscaling.MetricAnnotationKey] = string(spec.Metric)
+	annotations[autoscaling.TargetAnnotationKey] = strconv.Itoa(spec.Target)
+	annotations[autoscaling.TargetUtilizationPercentageKey] = spec.TargetUtilization
+	annotations[autoscaling.WindowAnnotationKey] = spec.Window
+	annotations[autoscaling.PanicWindowPercentageAnnotationKey] = spec.PanicWindow
+	annotations[autoscaling.PanicThresholdPercentageAnnotationKey] = spec.PanicThreshold
+	annotations[autoscaling.MinScaleAnnotationKey] = strconv.Itoa(spec.MinScale)
+	annotations[autoscaling.MaxScaleAnnotationKey] = strconv.Itoa(spec.MaxScale)
 
 	return annotations, nil
 }
-
-func (b *InferenceLoggerBuilder) buildResources(metadata metav1.ObjectMeta, spec monitoringv1beta1.InferenceLoggerSpec) (corev1.ResourceRequirements, error) {
-
-	defaultResources := corev1.ResourceList{
-		corev1.ResourceCPU:    resource.MustParse(constants.InferenceLoggerDefaultCPU),
-		corev1.ResourceMemory: resource.MustParse(constants.InferenceLoggerDefaultMemory),
-	}
-
-	if spec.Resources.Requests == nil {
-		spec.Resources.Requests = defaultResources
-	} else {
-		for name, value := range defaultResources {
-			if _, ok := spec.Resources.Requests[name]; !ok {
-				spec.Resources.Requests[name] = value
-			}
-		}
-	}
-
-	if spec.Resources.Limits == nil {
-		spec.Resources.Limits = defaultResources
-	} else {
-		for name, value := range defaultResources {
-			if _, ok := spec.Resources.Limits[name]; !ok {
-				spec.Resources.Limits[name] = value
-			}
-		}
-	}
-
-	return spec.Resources, nil
-}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func secretKey(name string, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
+
+// newTestSecuredModelMonitor returns a ModelMonitor connecting to Kafka with TLS and SASL credentials from Secrets
+func newTestSecuredModelMonitor() *monitoringv1beta1.ModelMonitor {
+	mm := &monitoringv1beta1.ModelMonitor{}
+	mm.Name = "iris"
+	mm.Namespace = "default"
+	mm.Spec.Model.Name = "iris"
+	mm.Spec.Storage.Inference.Kafka = monitoringv1beta1.KafkaSpec{
+		Brokers: "broker:9093",
+		Topic:   monitoringv1beta1.KafkaTopicSpec{Name: "inference"},
+		TLS:     &monitoringv1beta1.KafkaTLSSpec{CACert: secretKey("kafka-tls", "ca.crt")},
+		SASL: &monitoringv1beta1.KafkaSASLSpec{
+			Mechanism: monitoringv1beta1.PlainSASLMechanism,
+			Username:  *secretKey("kafka-credentials", "username"),
+			Password:  *secretKey("kafka-credentials", "password"),
+		},
+	}
+	return mm
+}
+
+func TestInferenceLoggerServiceMountsKafkaSecrets(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &monitoringv1beta1.ModelMonitorConfig{InferenceLogger: &monitoringv1beta1.InferenceLoggerConfig{ContainerImage: "logger:latest"}}
+
+	service, err := NewInferenceLoggerBuilder(config, ctrl.Log).CreateInferenceLoggerService("iris-logger", newTestSecuredModelMonitor())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	podSpec := service.Spec.Template.Spec.PodSpec
+	g.Expect(podSpec.Volumes).To(gomega.Equal([]corev1.Volume{
+		{Name: "secret-0", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kafka-credentials"}}},
+		{Name: "secret-1", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kafka-tls"}}},
+	}))
+	container := podSpec.Containers[0]
+	g.Expect(container.VolumeMounts).To(gomega.Equal([]corev1.VolumeMount{
+		{Name: "secret-0", MountPath: constants.SecretsMountPath + "/kafka-credentials", ReadOnly: true},
+		{Name: "secret-1", MountPath: constants.SecretsMountPath + "/kafka-tls", ReadOnly: true},
+	}))
+
+	env := map[string]corev1.EnvVar{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar
+	}
+	g.Expect(env[constants.InferenceLoggerEnvKafkaSecurityProtocolLabel].Value).To(gomega.Equal("SASL_SSL"))
+	g.Expect(env[constants.InferenceLoggerEnvKafkaSSLCALocationLabel].Value).To(gomega.Equal(constants.SecretsMountPath + "/kafka-tls/ca.crt"))
+	g.Expect(env[constants.InferenceLoggerEnvKafkaSASLMechanismLabel].Value).To(gomega.Equal("PLAIN"))
+
+	// SASL credentials are read by the kubelet from the Secrets, never inlined
+	for _, name := range []string{constants.InferenceLoggerEnvKafkaSASLUsernameLabel, constants.InferenceLoggerEnvKafkaSASLPasswordLabel} {
+		g.Expect(env[name].Value).To(gomega.BeEmpty())
+		g.Expect(env[name].ValueFrom.SecretKeyRef.Name).To(gomega.Equal("kafka-credentials"))
+	}
+	g.Expect(env[constants.InferenceLoggerEnvKafkaSASLPasswordLabel].ValueFrom.SecretKeyRef.Key).To(gomega.Equal("password"))
+}
+
+func TestInferenceLoggerServiceWithoutKafkaSecurity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &monitoringv1beta1.ModelMonitorConfig{InferenceLogger: &monitoringv1beta1.InferenceLoggerConfig{ContainerImage: "logger:latest"}}
+	mm := newTestSecuredModelMonitor()
+	mm.Spec.Storage.Inference.Kafka.TLS = nil
+	mm.Spec.Storage.Inference.Kafka.SASL = nil
+
+	service, err := NewInferenceLoggerBuilder(config, ctrl.Log).CreateInferenceLoggerService("iris-logger", mm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	podSpec := service.Spec.Template.Spec.PodSpec
+	g.Expect(podSpec.Volumes).To(gomega.BeEmpty())
+	g.Expect(podSpec.Containers[0].VolumeMounts).To(gomega.BeEmpty())
+	for _, envVar := range podSpec.Containers[0].Env {
+		g.Expect(envVar.Name).NotTo(gomega.Equal(constants.InferenceLoggerEnvKafkaSecurityProtocolLabel))
+	}
+}
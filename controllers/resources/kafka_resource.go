@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetKafkaSecurityConfig reads the credentials referenced by a Kafka spec from Secrets in the given namespace.
+// The operator passes its API reader, so Secrets are read on demand instead of being cached cluster-wide.
+// It returns nil if neither TLS nor SASL are enabled.
+func GetKafkaSecurityConfig(c client.Reader, namespace string, spec *monitoringv1beta1.KafkaSpec) (*kafka.SecurityConfig, error) {
+	if spec.TLS == nil && spec.SASL == nil {
+		return nil, nil
+	}
+
+	security := &kafka.SecurityConfig{}
+	if spec.TLS != nil {
+		var pems [3][]byte
+		for i, selector := range []*corev1.SecretKeySelector{spec.TLS.CACert, spec.TLS.Cert, spec.TLS.Key} {
+			if selector == nil {
+				continue
+			}
+			value, err := getSecretKey(c, namespace, selector)
+			if err != nil {
+				return nil, err
+			}
+			pems[i] = value
+		}
+		tlsConfig, err := kafka.NewTLSConfig(pems[0], pems[1], pems[2])
+		if err != nil {
+			return nil, err
+		}
+		security.TLS = tlsConfig
+	}
+	if spec.SASL != nil {
+		username, err := getSecretKey(c, namespace, &spec.SASL.Username)
+		if err != nil {
+			return nil, err
+		}
+		password, err := getSecretKey(c, namespace, &spec.SASL.Password)
+		if err != nil {
+			return nil, err
+		}
+		security.SASLMechanism = string(spec.SASL.Mechanism)
+		security.SASLUsername = string(username)
+		security.SASLPassword = string(password)
+	}
+	return security, nil
+}
+
+func getSecretKey(c client.Reader, namespace string, selector *corev1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret); err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("Key %q not found in Secret %s/%s", selector.Key, namespace, selector.Name)
+	}
+	return value, nil
+}
+
+// kafkaSecretPath returns the path where a Secret key is mounted
+func kafkaSecretPath(selector *corev1.SecretKeySelector) string {
+	return secretMountPath(selector.Name) + "/" + selector.Key
+}
+
+// buildKafkaSecurityEnvVars builds the env vars pointing to the mounted TLS files and the SASL credentials
+func buildKafkaSecurityEnvVars(spec *monitoringv1beta1.KafkaSpec) []corev1.EnvVar {
+	if spec.TLS == nil && spec.SASL == nil {
+		return nil
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: constants.InferenceLoggerEnvKafkaSecurityProtocolLabel, Value: spec.SecurityProtocol()},
+	}
+	if spec.TLS != nil {
+		locations := []struct {
+			name     string
+			selector *corev1.SecretKeySelector
+		}{
+			{constants.InferenceLoggerEnvKafkaSSLCALocationLabel, spec.TLS.CACert},
+			{constants.InferenceLoggerEnvKafkaSSLCertLocationLabel, spec.TLS.Cert},
+			{constants.InferenceLoggerEnvKafkaSSLKeyLocationLabel, spec.TLS.Key},
+		}
+		for _, location := range locations {
+			if location.selector != nil {
+				envVars = append(envVars, corev1.EnvVar{Name: location.name, Value: kafkaSecretPath(location.selector)})
+			}
+		}
+	}
+	if spec.SASL != nil {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: constants.InferenceLoggerEnvKafkaSASLMechanismLabel, Value: string(spec.SASL.Mechanism)},
+			corev1.EnvVar{
+				Name:      constants.InferenceLoggerEnvKafkaSASLUsernameLabel,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: spec.SASL.Username.DeepCopy()},
+			},
+			corev1.EnvVar{
+				Name:      constants.InferenceLoggerEnvKafkaSASLPasswordLabel,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: spec.SASL.Password.DeepCopy()},
+			},
+		)
+	}
+	return envVars
+}
@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateMonitoringJobDeployment creates the Deployment running the streaming Monitoring job on the deployment backend,
+// or nil if the job is scheduled. The job runs in a single replica, replaced on spec changes, with the driver resources.
+func (b *MonitoringJobBuilder) CreateMonitoringJobDeployment(monitoringJobName string, modelMonitor *monitoringv1beta1.ModelMonitor) (*appsv1.Deployment, error) {
+	if modelMonitor.Spec.Monitoring.Trigger.Schedule != nil {
+		return nil, nil
+	}
+
+	// Specs
+	metadata := modelMonitor.ObjectMeta
+	monitoringSpec := modelMonitor.Spec.Monitoring
+	storageSpec := modelMonitor.Spec.Storage
+	jobSpec := modelMonitor.Spec.Job
+
+	// Env vars (json format), sorted to keep the Deployment stable
+	envVars, err := buildMonitoringJobEnvVars(modelMonitor)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var env []corev1.EnvVar
+	for _, name := range names {
+		env = append(env, corev1.EnvVar{Name: name, Value: envVars[name]})
+	}
+
+	// Sink credentials and baselines, mounted at the same paths as in the Spark pods, and the job volumes
+	volumes, volumeMounts := buildSecretVolumes(append(storageSpec.SecretNames(), monitoringSpec.SecretNames()...))
+	configMapVolumes, configMapVolumeMounts := buildConfigMapVolumes(monitoringSpec.ConfigMapNames())
+	volumes = append(volumes, configMapVolumes...)
+	volumeMounts = append(volumeMounts, configMapVolumeMounts...)
+	volumes = append(volumes, jobSpec.Volumes...)
+	volumeMounts = append(volumeMounts, jobSpec.VolumeMounts...)
+
+	resources, err := buildJobResources(&jobSpec.Driver.ResourcesSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	podLabels := map[string]string{constants.ModelMonitorPodLabelKey: monitoringJobName}
+	for key, value := range metadata.Labels {
+		podLabels[key] = value
+	}
+	replicas := constants.MonitoringJobDeploymentReplicas
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      monitoringJobName,
+			Namespace: metadata.Namespace,
+			Labels:    metadata.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{constants.ModelMonitorPodLabelKey: monitoringJobName},
+			},
+			// Never two jobs consuming the inferences at once
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: constants.DefaultServiceAccountName(b.Permissions.Assignee),
+					Containers: []corev1.Container{
+						{
+							Name:            constants.MonitoringJobNameSuffix,
+							Image:           b.ModelMonitorConfig.Job.ContainerImage,
+							ImagePullPolicy: corev1.PullPolicy(constants.MonitoringJobImagePullPolicy),
+							Env:             env,
+							Resources:       resources,
+							VolumeMounts:    volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+	return deployment, nil
+}
+
+// buildJobResources converts the Spark resources of the job to container resources
+func buildJobResources(resources *monitoringv1beta1.ResourcesSpec) (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	if resources.Cores > 0 {
+		requirements.Requests[corev1.ResourceCPU] = *resource.NewQuantity(int64(resources.Cores), resource.DecimalSI)
+	}
+	if resources.CoreLimit != "" {
+		coreLimit, err := resource.ParseQuantity(resources.CoreLimit)
+		if err != nil {
+			return requirements, fmt.Errorf("Invalid core limit %q: %v", resources.CoreLimit, err)
+		}
+		requirements.Limits[corev1.ResourceCPU] = coreLimit
+	}
+	if resources.Memory != "" {
+		memory, err := kubernetesMemory(resources.Memory)
+		if err != nil {
+			return requirements, err
+		}
+		requirements.Requests[corev1.ResourceMemory] = memory
+		requirements.Limits[corev1.ResourceMemory] = memory
+	}
+	return requirements, nil
+}
+
+// sparkMemoryRegexp matches a Spark memory string, capturing the amount, the unit and the byte suffix
+var sparkMemoryRegexp = regexp.MustCompile(`(?i)^([0-9]+)([kmgtp])?(b)?$`)
+
+// kubernetesMemory converts a Spark memory string, in MiB if no unit is set (e.g 512m, 1g), to a quantity
+func kubernetesMemory(sparkMemory string) (resource.Quantity, error) {
+	match := sparkMemoryRegexp.FindStringSubmatch(sparkMemory)
+	if match == nil {
+		return resource.Quantity{}, fmt.Errorf("Invalid memory %q", sparkMemory)
+	}
+	amount, unit, bytes := match[1], strings.ToUpper(match[2]), match[3]
+
+	suffix := unit + "i"
+	switch {
+	case unit == "" && bytes != "":
+		suffix = ""
+	case unit == "":
+		suffix = "Mi"
+	}
+	return resource.ParseQuantity(amount + suffix)
+}
@@ -3,13 +3,13 @@ package resources
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
 	"github.com/javierdlrm/model-monitoring-operator/constants"
 
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
@@ -23,50 +23,72 @@ type MonitoringJobBuilder struct {
 }
 
 // NewMonitoringJobBuilder creates a Monitoring job builder
-func NewMonitoringJobBuilder(config *corev1.ConfigMap, log logr.Logger) *MonitoringJobBuilder {
-	modelMonitorConfig, err := monitoringv1beta1.NewModelMonitorConfig(config)
-	if err != nil {
-		fmt.Printf("Failed to get model monitor config %s", err.Error())
-		panic("Failed to get model monitor config")
-	}
+func NewMonitoringJobBuilder(config *monitoringv1beta1.ModelMonitorConfig, log logr.Logger) *MonitoringJobBuilder {
 	return &MonitoringJobBuilder{
-		ModelMonitorConfig: modelMonitorConfig,
+		ModelMonitorConfig: config,
 		Permissions:        NewPermissionsBuilder(constants.MonitoringJobAssignee, config, log),
 		Log:                log,
 	}
 }
 
-// CreateMonitoringJobSparkApp creates the Spark Application for Monitoring job
+// CreateMonitoringJobSparkApp creates the streaming Spark Application for Monitoring job, or nil if the job is
+// scheduled
 func (b *MonitoringJobBuilder) CreateMonitoringJobSparkApp(monitoringJobName string, modelMonitor *monitoringv1beta1.ModelMonitor) (*sparkv1beta2.SparkApplication, error) {
+	if modelMonitor.Spec.Monitoring.Trigger.Schedule != nil {
+		return nil, nil
+	}
+	return b.buildMonitoringJobSparkApp(monitoringJobName, modelMonitor)
+}
+
+// CreateMonitoringJobScheduledSparkApp creates the Scheduled Spark Application running the Monitoring job as a batch
+// application on the trigger schedule, or nil if the job is not scheduled. Runs are skipped while the previous one is
+// still running.
+func (b *MonitoringJobBuilder) CreateMonitoringJobScheduledSparkApp(monitoringJobName string, modelMonitor *monitoringv1beta1.ModelMonitor) (*sparkv1beta2.ScheduledSparkApplication, error) {
+	schedule := modelMonitor.Spec.Monitoring.Trigger.Schedule
+	if schedule == nil {
+		return nil, nil
+	}
+
+	sparkApp, err := b.buildMonitoringJobSparkApp(monitoringJobName, modelMonitor)
+	if err != nil {
+		return nil, err
+	}
+	scheduledSparkApp := &sparkv1beta2.ScheduledSparkApplication{
+		ObjectMeta: sparkApp.ObjectMeta,
+		Spec: sparkv1beta2.ScheduledSparkApplicationSpec{
+			Schedule:                  schedule.Cron,
+			Template:                  sparkApp.Spec,
+			ConcurrencyPolicy:         sparkv1beta2.ConcurrencyForbid,
+			SuccessfulRunHistoryLimit: &constants.MonitoringJobRunHistoryLimit,
+			FailedRunHistoryLimit:     &constants.MonitoringJobRunHistoryLimit,
+		},
+	}
+	return scheduledSparkApp, nil
+}
+
+// buildMonitoringJobSparkApp builds the Spark Application running the Monitoring job image with the ModelMonitor config
+func (b *MonitoringJobBuilder) buildMonitoringJobSparkApp(monitoringJobName string, modelMonitor *monitoringv1beta1.ModelMonitor) (*sparkv1beta2.SparkApplication, error) {
 
 	// Specs
 	metadata := modelMonitor.ObjectMeta
-	modelSpec := modelMonitor.Spec.Model
 	monitoringSpec := modelMonitor.Spec.Monitoring
 	storageSpec := modelMonitor.Spec.Storage
-	jobSpec := b.fillDriverAndExecutorResources(modelMonitor.Spec.Job)
+	jobSpec := modelMonitor.Spec.Job
 
 	// Service account
 	serviceAccount := constants.DefaultServiceAccountName(b.Permissions.Assignee)
 
 	// Env vars (json format)
-	modelSpecBytes, err := json.Marshal(modelSpec)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to marshal %v object to %v ", modelSpec, err)
-	}
-	monitoringSpecBytes, err := json.Marshal(monitoringSpec)
+	envVars, err := buildMonitoringJobEnvVars(modelMonitor)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to marshal %v object to %v ", monitoringSpec, err)
-	}
-	storageSpecBytes, err := json.Marshal(storageSpec)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to marshal %v object to %v ", storageSpec, err)
-	}
-	jobSpecBytes, err := json.Marshal(jobSpec)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to marshal %v object to %v ", jobSpec, err)
+		return nil, err
 	}
 
+	// Sink credentials and baselines, mounted from Secrets and ConfigMaps. Only references are passed in the storage
+	// and monitoring configs.
+	secrets := buildSparkSecrets(append(storageSpec.SecretNames(), monitoringSpec.SecretNames()...))
+	configMaps := buildSparkConfigMaps(monitoringSpec.ConfigMapNames())
+
 	// Spark application
 	sparkApp := &sparkv1beta2.SparkApplication{
 		ObjectMeta: metav1.ObjectMeta{
@@ -82,20 +104,11 @@ func (b *MonitoringJobBuilder) CreateMonitoringJobSparkApp(monitoringJobName str
 			MainClass:           &b.ModelMonitorConfig.Job.MainClass,
 			MainApplicationFile: &b.ModelMonitorConfig.Job.MainApplicationFile,
 			SparkVersion:        constants.MonitoringJobSparkVersion,
+			// Restarted by the operator with backoff, according to the job restart policy
 			RestartPolicy: sparkv1beta2.RestartPolicy{
 				Type: sparkv1beta2.Never,
 			},
-			Volumes: []corev1.Volume{
-				{
-					Name: constants.MonitoringJobVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						HostPath: &corev1.HostPathVolumeSource{
-							Path: constants.MonitoringJobVolumeHostPath,
-							Type: &constants.MonitoringJobVolumeHostPathType,
-						},
-					},
-				},
-			},
+			Volumes: jobSpec.Volumes,
 			Driver: sparkv1beta2.DriverSpec{
 				SparkPodSpec: sparkv1beta2.SparkPodSpec{
 					Cores:          &jobSpec.Driver.Cores,
@@ -103,32 +116,21 @@ func (b *MonitoringJobBuilder) CreateMonitoringJobSparkApp(monitoringJobName str
 					Memory:         &jobSpec.Driver.Memory,
 					Labels:         map[string]string{constants.MonitoringJobSparkVersionLabel: constants.MonitoringJobSparkVersion},
 					ServiceAccount: &serviceAccount,
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      constants.MonitoringJobVolumeMountName,
-							MountPath: constants.MonitoringJobVolumeMountPath,
-						},
-					},
-					EnvVars: map[string]string{
-						constants.MonitoringJobEnvVarModelInfoLabel:        string(modelSpecBytes),
-						constants.MonitoringJobEnvVarMonitoringConfigLabel: string(monitoringSpecBytes),
-						constants.MonitoringJobEnvVarStorageConfigLabel:    string(storageSpecBytes),
-						constants.MonitoringJobEnvVarJobConfigLabel:        string(jobSpecBytes),
-					},
+					Secrets:        secrets,
+					ConfigMaps:     configMaps,
+					VolumeMounts:   jobSpec.VolumeMounts,
+					EnvVars:        envVars,
 				},
 			},
 			Executor: sparkv1beta2.ExecutorSpec{
 				SparkPodSpec: sparkv1beta2.SparkPodSpec{
-					Cores:     &jobSpec.Executor.Cores,
-					CoreLimit: &jobSpec.Executor.CoreLimit,
-					Memory:    &jobSpec.Executor.Memory,
-					Labels:    map[string]string{constants.MonitoringJobSparkVersionLabel: constants.MonitoringJobSparkVersion},
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      constants.MonitoringJobVolumeMountName,
-							MountPath: constants.MonitoringJobVolumeMountPath,
-						},
-					},
+					Cores:        &jobSpec.Executor.Cores,
+					CoreLimit:    &jobSpec.Executor.CoreLimit,
+					Memory:       &jobSpec.Executor.Memory,
+					Labels:       map[string]string{constants.MonitoringJobSparkVersionLabel: constants.MonitoringJobSparkVersion},
+					Secrets:      secrets,
+					ConfigMaps:   configMaps,
+					VolumeMounts: jobSpec.VolumeMounts,
 				},
 				Instances: &jobSpec.Executor.Instances,
 			},
@@ -150,32 +152,171 @@ func (b *MonitoringJobBuilder) CreateMonitoringJobSparkApp(monitoringJobName str
 	return sparkApp, nil
 }
 
-func (b *MonitoringJobBuilder) fillDriverAndExecutorResources(job monitoringv1beta1.JobSpec) monitoringv1beta1.JobSpec {
+// buildMonitoringJobEnvVars builds the env vars configuring the Monitoring job, regardless of the backend
+func buildMonitoringJobEnvVars(modelMonitor *monitoringv1beta1.ModelMonitor) (map[string]string, error) {
+
+	// Specs
+	modelSpec := modelMonitor.Spec.Model
+	monitoringSpec := modelMonitor.Spec.Monitoring
+	storageSpec := modelMonitor.Spec.Storage
+	jobSpec := modelMonitor.Spec.Job
+
+	// Env vars (json format)
+	modelInfo, err := buildModelInfo(&modelSpec)
+	if err != nil {
+		return nil, err
+	}
+	modelInfo.Revision = modelMonitor.JobModelRevision()
+	modelSpecBytes, err := json.Marshal(modelInfo)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal %v object to %v ", modelInfo, err)
+	}
+	monitoringSpecBytes, err := buildMonitoringConfig(&monitoringSpec)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal %v object to %v ", monitoringSpec, err)
+	}
+	storageSpecBytes, err := json.Marshal(storageSpec)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal %v object to %v ", storageSpec, err)
+	}
+	// Restarts are applied by the operator and volumes mounted in the pods, they are not passed to the job
+	jobConfig := jobSpec
+	jobConfig.RestartPolicy = nil
+	jobConfig.Volumes = nil
+	jobConfig.VolumeMounts = nil
+	jobSpecBytes, err := json.Marshal(jobConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal %v object to %v ", jobSpec, err)
+	}
+
+	envVars := map[string]string{
+		constants.MonitoringJobEnvVarModelInfoLabel:        string(modelSpecBytes),
+		constants.MonitoringJobEnvVarMonitoringConfigLabel: string(monitoringSpecBytes),
+		constants.MonitoringJobEnvVarStorageConfigLabel:    string(storageSpecBytes),
+		constants.MonitoringJobEnvVarJobConfigLabel:        string(jobSpecBytes),
+		constants.MonitoringJobEnvVarSecretsPathLabel:      constants.SecretsMountPath,
+		constants.MonitoringJobEnvVarConfigMapsPathLabel:   constants.ConfigMapsMountPath,
+	}
 
-	// Driver
-	if job.Driver.Cores == 0 {
-		job.Driver.Cores = constants.MonitoringJobDriverCores
+	// Baseline computed from the serving revision
+	if modelSpec.OnVersionChange == monitoringv1beta1.RebaselineVersionChangePolicy && modelInfo.Revision != "" {
+		envVars[constants.MonitoringJobEnvVarBaselineRevisionLabel] = modelInfo.Revision
 	}
-	if job.Driver.CoreLimit == "" {
-		job.Driver.CoreLimit = constants.MonitoringJobDriverCoreLimit
+
+	// Baselines computed by the Baseline job, restarting the job when recomputed
+	if monitoringSpec.ComputesBaselines() && modelMonitor.Status.BaselineJob != nil {
+		envVars[constants.MonitoringJobEnvVarBaselineJobIDLabel] = modelMonitor.Status.BaselineJob.SparkApplicationID
 	}
-	if job.Driver.Memory == "" {
-		job.Driver.Memory = constants.MonitoringJobDriverMemory
+
+	// Analysis partitioned by revision
+	if monitoringSpec.PerRevision != nil {
+		envVars[constants.MonitoringJobEnvVarRevisionHeaderLabel] = constants.CloudEventEndpointHeader
 	}
 
-	// Executor
-	if job.Executor.Cores == 0 {
-		job.Executor.Cores = constants.MonitoringJobExecutorCores
+	// Streaming state kept across restarts
+	if jobSpec.CheckpointLocation != "" {
+		envVars[constants.MonitoringJobEnvVarCheckpointLocationLabel] = jobSpec.CheckpointLocation
 	}
-	if job.Executor.CoreLimit == "" {
-		job.Executor.CoreLimit = constants.MonitoringJobExecutorCoreLimit
+	return envVars, nil
+}
+
+// jobTrigger defines the trigger passed to the Monitoring job, with durations in milliseconds and tumbling windows
+// sliding by their length
+type jobTrigger struct {
+	Window     *jobWindow                         `json:"window,omitempty"`
+	Count      *monitoringv1beta1.CountWindowSpec `json:"count,omitempty"`
+	Schedule   *jobSchedule                       `json:"schedule,omitempty"`
+	MinSamples int64                              `json:"minSamples,omitempty"`
+}
+
+type jobWindow struct {
+	Duration       int64 `json:"duration"`
+	Slide          int64 `json:"slide"`
+	WatermarkDelay int64 `json:"watermarkDelay"`
+}
+
+type jobSchedule struct {
+	Cron     string `json:"cron"`
+	Lookback int64  `json:"lookback"`
+}
+
+// buildMonitoringConfig marshals the monitoring spec passed to the job, converting the trigger
+func buildMonitoringConfig(monitoringSpec *monitoringv1beta1.MonitoringSpec) ([]byte, error) {
+	specBytes, err := json.Marshal(monitoringSpec)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]json.RawMessage{}
+	if err := json.Unmarshal(specBytes, &config); err != nil {
+		return nil, err
+	}
+
+	trigger := monitoringSpec.Trigger
+	jobTrigger := jobTrigger{MinSamples: trigger.MinSamples}
+	if window := trigger.Window; window != nil {
+		slide := window.Duration
+		if window.Slide != nil {
+			slide = *window.Slide
+		}
+		jobTrigger.Window = &jobWindow{
+			Duration:       milliseconds(window.Duration),
+			Slide:          milliseconds(slide),
+			WatermarkDelay: milliseconds(window.WatermarkDelay),
+		}
 	}
-	if job.Executor.Memory == "" {
-		job.Executor.Memory = constants.MonitoringJobExecutorMemory
+	if count := trigger.Count; count != nil {
+		jobTrigger.Count = count.DeepCopy()
+		if jobTrigger.Count.Slide == 0 {
+			jobTrigger.Count.Slide = count.Size
+		}
 	}
-	if job.Executor.Instances == 0 {
-		job.Executor.Instances = constants.MonitoringJobExecutorInstances
+	if schedule := trigger.Schedule; schedule != nil {
+		jobTrigger.Schedule = &jobSchedule{Cron: schedule.Cron, Lookback: milliseconds(schedule.Lookback)}
 	}
+	if config["trigger"], err = json.Marshal(jobTrigger); err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
 
-	return job
+func milliseconds(duration monitoringv1beta1.Duration) int64 {
+	return int64(duration.Duration / time.Millisecond)
+}
+
+// modelInfo defines the model info passed to the Monitoring job, with the schemas as Spark StructType JSON strings
+type modelInfo struct {
+	Name     string                              `json:"name"`
+	ID       string                              `json:"id,omitempty"`
+	Version  *int                                `json:"version,omitempty"`
+	Protocol monitoringv1beta1.InferenceProtocol `json:"protocol"`
+	Revision string                              `json:"revision,omitempty"`
+	Tensors  *monitoringv1beta1.ModelTensorsSpec `json:"tensors,omitempty"`
+	Schemas  map[string]string                   `json:"schemas"`
+}
+
+func buildModelInfo(modelSpec *monitoringv1beta1.ModelSpec) (*modelInfo, error) {
+	modelSchemas := modelSpec.EffectiveSchemas()
+	schemas := map[string]*monitoringv1beta1.Schema{
+		"request":    &modelSchemas.Request,
+		"response":   &modelSchemas.Response,
+		"instance":   &modelSchemas.Instance,
+		"prediction": &modelSchemas.Prediction,
+	}
+
+	info := &modelInfo{
+		Name:     modelSpec.Name,
+		ID:       modelSpec.ID,
+		Version:  modelSpec.Version,
+		Protocol: modelSpec.Protocol,
+		Tensors:  modelSpec.Tensors,
+		Schemas:  map[string]string{},
+	}
+	for name, schema := range schemas {
+		sparkSchema, err := schema.SparkJSON()
+		if err != nil {
+			return nil, fmt.Errorf("Unable to convert %s schema to Spark schema: %v", name, err)
+		}
+		info.Schemas[name] = sparkSchema
+	}
+	return info, nil
 }
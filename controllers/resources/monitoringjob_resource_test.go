@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	"github.com/onsi/gomega"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestMonitoringJobSparkAppMountsSinkSecrets(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &monitoringv1beta1.ModelMonitorConfig{Job: &monitoringv1beta1.JobConfig{ContainerImage: "job:latest"}}
+	mm := newTestSecuredModelMonitor()
+	// Secrets shared with the inference topic are mounted once
+	stats := mm.Spec.Storage.Inference.Kafka.DeepCopy()
+	stats.Topic.Name = "stats"
+	mm.Spec.Storage.Analysis.Stats.Kafka = stats
+	mm.Spec.Storage.Analysis.Drift = &monitoringv1beta1.SinkSpec{S3: &monitoringv1beta1.S3SinkSpec{
+		Bucket:          "drift",
+		AccessKeyID:     secretKey("s3-credentials", "accessKeyId"),
+		SecretAccessKey: secretKey("s3-credentials", "secretAccessKey"),
+	}}
+
+	sparkApp, err := NewMonitoringJobBuilder(config, ctrl.Log).CreateMonitoringJobSparkApp("iris-job", mm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	secrets := []sparkv1beta2.SecretInfo{
+		{Name: "kafka-credentials", Path: constants.SecretsMountPath + "/kafka-credentials", Type: sparkv1beta2.GenericType},
+		{Name: "kafka-tls", Path: constants.SecretsMountPath + "/kafka-tls", Type: sparkv1beta2.GenericType},
+		{Name: "s3-credentials", Path: constants.SecretsMountPath + "/s3-credentials", Type: sparkv1beta2.GenericType},
+	}
+	g.Expect(sparkApp.Spec.Driver.Secrets).To(gomega.Equal(secrets))
+	g.Expect(sparkApp.Spec.Executor.Secrets).To(gomega.Equal(secrets))
+	g.Expect(sparkApp.Spec.Driver.EnvVars[constants.MonitoringJobEnvVarSecretsPathLabel]).To(gomega.Equal(constants.SecretsMountPath))
+
+	// The storage config only holds the Secret references, the job reads the values from the mounted files
+	var storage struct {
+		Inference struct {
+			Kafka map[string]json.RawMessage `json:"kafka"`
+		} `json:"inference"`
+		Analysis struct {
+			Drift struct {
+				S3 map[string]json.RawMessage `json:"s3"`
+			} `json:"drift"`
+		} `json:"analysis"`
+	}
+	g.Expect(json.Unmarshal([]byte(sparkApp.Spec.Driver.EnvVars[constants.MonitoringJobEnvVarStorageConfigLabel]), &storage)).To(gomega.Succeed())
+	g.Expect(storage.Inference.Kafka["sasl"]).To(gomega.MatchJSON(`{
+		"mechanism": "PLAIN",
+		"username": {"name": "kafka-credentials", "key": "username"},
+		"password": {"name": "kafka-credentials", "key": "password"}
+	}`))
+	g.Expect(storage.Inference.Kafka["tls"]).To(gomega.MatchJSON(`{"caCert": {"name": "kafka-tls", "key": "ca.crt"}}`))
+	g.Expect(storage.Analysis.Drift.S3["accessKeyId"]).To(gomega.MatchJSON(`{"name": "s3-credentials", "key": "accessKeyId"}`))
+	g.Expect(storage.Analysis.Drift.S3["secretAccessKey"]).To(gomega.MatchJSON(`{"name": "s3-credentials", "key": "secretAccessKey"}`))
+}
@@ -1,8 +1,6 @@
 package resources
 
 import (
-	"fmt"
-
 	"github.com/go-logr/logr"
 
 	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
@@ -21,32 +19,29 @@ type PermissionsBuilder struct {
 }
 
 // NewPermissionsBuilder creates a Permission builder
-func NewPermissionsBuilder(assignee string, config *corev1.ConfigMap, log logr.Logger) *PermissionsBuilder {
-	modelMonitorConfig, err := monitoringv1beta1.NewModelMonitorConfig(config)
-	if err != nil {
-		fmt.Printf("Failed to get model monitor config %s", err.Error())
-		panic("Failed to get model monitor config")
-	}
+func NewPermissionsBuilder(assignee string, config *monitoringv1beta1.ModelMonitorConfig, log logr.Logger) *PermissionsBuilder {
 	return &PermissionsBuilder{
 		Assignee:           assignee,
-		ModelMonitorConfig: modelMonitorConfig,
+		ModelMonitorConfig: config,
 		Log:                log,
 	}
 }
 
-// CreateServiceAccountRoleAndBinding creates a Service account, Role and Role binding
-func (b *PermissionsBuilder) CreateServiceAccountRoleAndBinding(modelMonitor *monitoringv1beta1.ModelMonitor) (*corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding, error) {
+// CreateServiceAccountRoleAndBinding creates a Service account, Role and Role binding. The permissions are shared by
+// the ModelMonitors in the namespace, so the Role grants access to the resources referenced by any of them.
+func (b *PermissionsBuilder) CreateServiceAccountRoleAndBinding(modelMonitor *monitoringv1beta1.ModelMonitor, modelMonitors []monitoringv1beta1.ModelMonitor) (*corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding, error) {
 	metadata := modelMonitor.ObjectMeta
 	serviceAccountName := constants.DefaultServiceAccountName(b.Assignee)
 	roleName := constants.DefaultRoleName(b.Assignee)
 	roleBindingName := constants.DefaultRoleBindingName(b.Assignee)
+	labels := permissionsLabels(metadata.Labels)
 
 	// Service account
 	serviceAccount := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceAccountName,
 			Namespace: metadata.Namespace,
-			Labels:    metadata.Labels,
+			Labels:    labels,
 		},
 	}
 	// Role
@@ -54,27 +49,16 @@ func (b *PermissionsBuilder) CreateServiceAccountRoleAndBinding(modelMonitor *mo
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      roleName,
 			Namespace: metadata.Namespace,
-			Labels:    metadata.Labels,
-		},
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{""},
-				Resources: []string{"pods"},
-				Verbs:     []string{"*"},
-			},
-			{
-				APIGroups: []string{""},
-				Resources: []string{"services"},
-				Verbs:     []string{"*"},
-			},
+			Labels:    labels,
 		},
+		Rules: b.rules(modelMonitors),
 	}
 	// Role Binding
 	roleBinding := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      roleBindingName,
 			Namespace: metadata.Namespace,
-			Labels:    metadata.Labels,
+			Labels:    labels,
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -91,3 +75,93 @@ func (b *PermissionsBuilder) CreateServiceAccountRoleAndBinding(modelMonitor *mo
 	}
 	return serviceAccount, role, roleBinding, nil
 }
+
+// permissionsLabels returns the ModelMonitor labels along with the label marking the permissions as managed by the operator
+func permissionsLabels(modelMonitorLabels map[string]string) map[string]string {
+	labels := map[string]string{constants.ManagedByLabelKey: constants.ControllerLabelName}
+	for key, value := range modelMonitorLabels {
+		if key != constants.ManagedByLabelKey {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// rules returns the policy rules of the assignee
+func (b *PermissionsBuilder) rules(modelMonitors []monitoringv1beta1.ModelMonitor) []rbacv1.PolicyRule {
+	if b.Assignee == constants.AlerterAssignee {
+		rules := []rbacv1.PolicyRule{
+			{
+				// Alerts recorded as Events of the ModelMonitor
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"create", "patch"},
+			},
+		}
+		// An empty list of resource names would grant access to every Secret
+		if secretNames := alerterSecretNames(modelMonitors); len(secretNames) > 0 {
+			rules = append(rules, rbacv1.PolicyRule{
+				// Kafka credentials and Slack webhooks
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: secretNames,
+				Verbs:         []string{"get"},
+			})
+		}
+		return rules
+	}
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"*"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"services"},
+			Verbs:     []string{"*"},
+		},
+	}
+	// An empty list of resource names would grant access to every ConfigMap
+	if configMapNames := baselineConfigMapNames(modelMonitors); len(configMapNames) > 0 {
+		rules = append(rules, rbacv1.PolicyRule{
+			// Baselines written by the Baseline job
+			APIGroups:     []string{""},
+			Resources:     []string{"configmaps"},
+			ResourceNames: configMapNames,
+			Verbs:         []string{"get", "update"},
+		})
+	}
+	return rules
+}
+
+// baselineConfigMapNames returns the names of the ConfigMaps written by the Baseline jobs of the ModelMonitors, sorted
+func baselineConfigMapNames(modelMonitors []monitoringv1beta1.ModelMonitor) []string {
+	var names []string
+	for i := range modelMonitors {
+		if modelMonitors[i].Spec.Monitoring.ComputesBaselines() {
+			names = append(names, constants.DefaultBaselineConfigMapName(modelMonitors[i].Name))
+		}
+	}
+	return sortedCopy(names)
+}
+
+// alerterSecretNames returns the names of the Secrets read by the alerters of the ModelMonitors, sorted and without
+// duplicates
+func alerterSecretNames(modelMonitors []monitoringv1beta1.ModelMonitor) []string {
+	var names []string
+	for i := range modelMonitors {
+		spec := &modelMonitors[i].Spec
+		alertingSpec := spec.Monitoring.Alerting
+		if alertingSpec == nil {
+			continue
+		}
+		names = append(names, alertingSpec.SecretNames()...)
+		for _, source := range alertingSpec.Sources() {
+			if kafka := spec.Storage.Analysis.AlertSourceKafka(source); kafka != nil {
+				names = append(names, kafka.SecretNames()...)
+			}
+		}
+	}
+	return sortedCopy(names)
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/javierdlrm/model-monitoring-operator/constants"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+)
+
+// secretMountPath returns the path where a Secret referenced by the sinks is mounted
+func secretMountPath(secretName string) string {
+	return constants.SecretsMountPath + "/" + secretName
+}
+
+// buildSecretVolumes mounts the given Secrets as read-only volumes
+func buildSecretVolumes(secretNames []string) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	for i, name := range sortedCopy(secretNames) {
+		volumeName := constants.SecretVolumePrefix + strconv.Itoa(i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: name},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: secretMountPath(name),
+			ReadOnly:  true,
+		})
+	}
+	return volumes, mounts
+}
+
+// buildConfigMapVolumes mounts the given ConfigMaps as volumes
+func buildConfigMapVolumes(configMapNames []string) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	for i, name := range sortedCopy(configMapNames) {
+		volumeName := constants.ConfigMapVolumePrefix + strconv.Itoa(i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: constants.ConfigMapsMountPath + "/" + name,
+			ReadOnly:  true,
+		})
+	}
+	return volumes, mounts
+}
+
+// buildSparkSecrets mounts the given Secrets in the Spark pods
+func buildSparkSecrets(secretNames []string) []sparkv1beta2.SecretInfo {
+	var secrets []sparkv1beta2.SecretInfo
+	for _, name := range sortedCopy(secretNames) {
+		secrets = append(secrets, sparkv1beta2.SecretInfo{
+			Name: name,
+			Path: secretMountPath(name),
+			Type: sparkv1beta2.GenericType,
+		})
+	}
+	return secrets
+}
+
+// buildSparkConfigMaps mounts the given ConfigMaps in the Spark pods
+func buildSparkConfigMaps(configMapNames []string) []sparkv1beta2.NamePath {
+	var configMaps []sparkv1beta2.NamePath
+	for _, name := range sortedCopy(configMapNames) {
+		configMaps = append(configMaps, sparkv1beta2.NamePath{
+			Name: name,
+			Path: constants.ConfigMapsMountPath + "/" + name,
+		})
+	}
+	return configMaps
+}
+
+// sortedCopy keeps the generated resources stable regardless of the order of the spec, dropping duplicates
+// (e.g Secrets referenced by both sinks and baselines)
+func sortedCopy(values []string) []string {
+	var sorted []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			sorted = append(sorted, value)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
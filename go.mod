@@ -4,17 +4,20 @@ go 1.13
 
 require (
 	github.com/GoogleCloudPlatform/spark-on-k8s-operator v0.0.0-20200603170112-9d4592a0fc69
+	github.com/Shopify/sarama v1.23.1
 	github.com/go-logr/logr v0.1.0
 	github.com/kubeflow/kfserving v0.3.0
 	github.com/onsi/ginkgo v1.11.0
 	github.com/onsi/gomega v1.8.1
 	github.com/prometheus/common v0.9.1
+	github.com/xdg/scram v0.0.0-20180814205039-7eeb5667e42c
 	k8s.io/api v0.17.4
 	k8s.io/apimachinery v0.17.4
 	k8s.io/client-go v11.0.1-0.20190805182717-6502b5e7b1b5+incompatible
 	knative.dev/pkg v0.0.0-20200519155757-14eb3ae3a5a7
 	knative.dev/serving v0.15.0
 	sigs.k8s.io/controller-runtime v0.5.0
+	sigs.k8s.io/yaml v1.1.0
 )
 
 replace (
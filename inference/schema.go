@@ -0,0 +1,193 @@
+// Package inference infers the ModelMonitor schemas from logged inference payloads
+package inference
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+)
+
+// KFServing v1 protocol payload keys
+const (
+	InstancesKey   = "instances"
+	PredictionsKey = "predictions"
+)
+
+// Default names of the features of array instances and predictions, suffixed by position
+const (
+	DefaultInstanceFieldPrefix   = "feature_"
+	DefaultPredictionFieldPrefix = "prediction_"
+)
+
+// DefaultMaxCategoricalValues is the maximum number of allowed values listed for categorical features
+const DefaultMaxCategoricalValues = 20
+
+// SchemaInferrer infers the model schemas from KFServing v1 request and response payloads
+type SchemaInferrer struct {
+	// Names of the features of array instances and predictions, by position
+	InstanceFields   []string
+	PredictionFields []string
+	// Whether to list the values seen of categorical features as their allowed values. Samples rarely contain all
+	// the allowed values, so they are not listed by default.
+	CategoricalValues bool
+	// Categorical features with more distinct values don't list them
+	MaxCategoricalValues int
+
+	request, response    *valueType
+	instance, prediction *elementsType
+}
+
+// elementsType accumulates the type of instances or predictions. Arrays of scalars are inferred by position.
+type elementsType struct {
+	positional []*valueType
+	value      *valueType
+}
+
+// NewSchemaInferrer creates a schema inferrer
+func NewSchemaInferrer(instanceFields []string, predictionFields []string) *SchemaInferrer {
+	return &SchemaInferrer{
+		InstanceFields:       instanceFields,
+		PredictionFields:     predictionFields,
+		MaxCategoricalValues: DefaultMaxCategoricalValues,
+		instance:             &elementsType{},
+		prediction:           &elementsType{},
+	}
+}
+
+// Add adds a payload to the sample. Requests are identified by the instances key and responses by the predictions key.
+func (i *SchemaInferrer) Add(payload []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var body map[string]interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return fmt.Errorf("Invalid payload, expected a JSON object: %v", err)
+	}
+
+	instances, isRequest := body[InstancesKey].([]interface{})
+	predictions, isResponse := body[PredictionsKey].([]interface{})
+	if !isRequest && !isResponse {
+		return fmt.Errorf("Invalid payload, expected a KFServing v1 request or response with %s or %s", InstancesKey, PredictionsKey)
+	}
+
+	if isRequest {
+		i.request = merge(i.request, typeOf(body, i.MaxCategoricalValues), i.MaxCategoricalValues)
+		i.instance.add(instances, i.MaxCategoricalValues)
+	}
+	if isResponse {
+		i.response = merge(i.response, typeOf(body, i.MaxCategoricalValues), i.MaxCategoricalValues)
+		i.prediction.add(predictions, i.MaxCategoricalValues)
+	}
+	return nil
+}
+
+// Schemas returns the inferred schemas. At least one request and one response are required.
+func (i *SchemaInferrer) Schemas() (*monitoringv1beta1.ModelSchemasSpec, error) {
+	if i.request == nil {
+		return nil, fmt.Errorf("No requests found, unable to infer the request and instance schemas")
+	}
+	if i.response == nil {
+		return nil, fmt.Errorf("No responses found, unable to infer the response and prediction schemas")
+	}
+
+	instance, err := i.instance.schema(i.InstanceFields, DefaultInstanceFieldPrefix, i.CategoricalValues)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to infer the instance schema: %v", err)
+	}
+	prediction, err := i.prediction.schema(i.PredictionFields, DefaultPredictionFieldPrefix, i.CategoricalValues)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to infer the prediction schema: %v", err)
+	}
+
+	return &monitoringv1beta1.ModelSchemasSpec{
+		Request:    *structSchema(i.request, false),
+		Response:   *structSchema(i.response, false),
+		Instance:   *instance,
+		Prediction: *prediction,
+	}, nil
+}
+
+func (e *elementsType) add(elements []interface{}, maxDistinctValues int) {
+	for _, element := range elements {
+		if values, ok := element.([]interface{}); ok && allScalars(values) {
+			for len(e.positional) < len(values) {
+				e.positional = append(e.positional, nil)
+			}
+			for pos, value := range values {
+				e.positional[pos] = merge(e.positional[pos], typeOf(value, maxDistinctValues), maxDistinctValues)
+			}
+			continue
+		}
+		e.value = merge(e.value, typeOf(element, maxDistinctValues), maxDistinctValues)
+	}
+}
+
+func (e *elementsType) schema(names []string, defaultPrefix string, listValues bool) (*monitoringv1beta1.Schema, error) {
+	switch {
+	case e.positional != nil && e.value != nil:
+		return nil, fmt.Errorf("elements mix arrays of scalars with other values")
+	case e.positional != nil:
+		if len(names) > 0 && len(names) != len(e.positional) {
+			return nil, fmt.Errorf("%d field names given but elements have %d values", len(names), len(e.positional))
+		}
+		schema := &monitoringv1beta1.Schema{}
+		for pos, t := range e.positional {
+			name := defaultPrefix + strconv.Itoa(pos)
+			if len(names) > 0 {
+				name = names[pos]
+			}
+			schema.Fields = append(schema.Fields, schemaField(name, t, listValues))
+		}
+		return schema, nil
+	case e.value != nil && e.value.kind == structKind:
+		return structSchema(e.value, listValues), nil
+	case e.value != nil:
+		name := defaultPrefix + "0"
+		if len(names) > 0 {
+			name = names[0]
+		}
+		return &monitoringv1beta1.Schema{Fields: []monitoringv1beta1.SchemaField{schemaField(name, e.value, listValues)}}, nil
+	}
+	return &monitoringv1beta1.Schema{}, nil
+}
+
+func structSchema(t *valueType, listValues bool) *monitoringv1beta1.Schema {
+	schema := &monitoringv1beta1.Schema{}
+	for _, name := range t.fieldNames() {
+		schema.Fields = append(schema.Fields, schemaField(name, t.fields[name], listValues))
+	}
+	return schema
+}
+
+// schemaField builds a field, marking scalars as categorical or numerical features. The values seen of categorical
+// features are only listed if requested.
+func schemaField(name string, t *valueType, listValues bool) monitoringv1beta1.SchemaField {
+	field := monitoringv1beta1.SchemaField{Name: name, Type: t.sparkType()}
+	switch t.kind {
+	case longKind, doubleKind:
+		field.Kind = monitoringv1beta1.NumericalFeatureKind
+	case stringKind, booleanKind:
+		field.Kind = monitoringv1beta1.CategoricalFeatureKind
+		if !listValues {
+			break
+		}
+		for value := range t.values {
+			field.Values = append(field.Values, value)
+		}
+		sort.Strings(field.Values)
+	}
+	return field
+}
+
+func allScalars(values []interface{}) bool {
+	for _, value := range values {
+		switch value.(type) {
+		case []interface{}, map[string]interface{}:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package inference
+
+import (
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/onsi/gomega"
+)
+
+func TestSchemaInferrerArrayInstances(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewSchemaInferrer([]string{"sepal_length", "sepal_width", "petal_length", "petal_width"}, nil)
+
+	g.Expect(inferrer.Add([]byte(`{"instances": [[6.8, 2.8, 4.8, 1.4], [6, 3, 5, 2]]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"predictions": [1, 2]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"unknown": true}`))).NotTo(gomega.Succeed())
+
+	schemas, err := inferrer.Schemas()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(schemas.Request.Fields).To(gomega.Equal([]monitoringv1beta1.SchemaField{
+		{Name: "instances", Type: "array<array<double>>"},
+	}))
+	g.Expect(schemas.Response.Fields).To(gomega.Equal([]monitoringv1beta1.SchemaField{
+		{Name: "predictions", Type: "array<long>"},
+	}))
+	g.Expect(schemas.Instance.Fields).To(gomega.HaveLen(4))
+	g.Expect(schemas.Instance.Fields[0]).To(gomega.Equal(monitoringv1beta1.SchemaField{
+		Name: "sepal_length", Type: "double", Kind: monitoringv1beta1.NumericalFeatureKind,
+	}))
+	g.Expect(schemas.Prediction.Fields).To(gomega.Equal([]monitoringv1beta1.SchemaField{
+		{Name: "prediction_0", Type: "long", Kind: monitoringv1beta1.NumericalFeatureKind},
+	}))
+}
+
+func TestSchemaInferrerObjectInstances(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewSchemaInferrer(nil, nil)
+
+	g.Expect(inferrer.Add([]byte(`{"instances": [{"age": 30, "country": "SE"}, {"age": null, "country": "ES", "tags": ["a"]}]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"predictions": [[0.1, 0.9], [0.7, 0.3]]}`))).To(gomega.Succeed())
+
+	schemas, err := inferrer.Schemas()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(schemas.Request.Fields[0].Type).To(gomega.Equal("array<struct<age:long,country:string,tags:array<string>>>"))
+	g.Expect(schemas.Instance.Fields).To(gomega.Equal([]monitoringv1beta1.SchemaField{
+		{Name: "age", Type: "long", Kind: monitoringv1beta1.NumericalFeatureKind},
+		{Name: "country", Type: "string", Kind: monitoringv1beta1.CategoricalFeatureKind},
+		{Name: "tags", Type: "array<string>"},
+	}))
+	g.Expect(schemas.Prediction.Fields).To(gomega.HaveLen(2))
+	g.Expect(schemas.Prediction.Fields[1].Name).To(gomega.Equal("prediction_1"))
+
+	for _, schema := range []monitoringv1beta1.Schema{schemas.Request, schemas.Response, schemas.Instance, schemas.Prediction} {
+		_, err := schema.SparkJSON()
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+}
+
+func TestSchemaInferrerRequiresRequestsAndResponses(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewSchemaInferrer(nil, nil)
+	g.Expect(inferrer.Add([]byte(`{"instances": [[1]]}`))).To(gomega.Succeed())
+
+	_, err := inferrer.Schemas()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestSchemaInferrerCategoricalValues(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewSchemaInferrer(nil, nil)
+	inferrer.CategoricalValues = true
+
+	g.Expect(inferrer.Add([]byte(`{"instances": [{"country": "SE"}, {"country": "ES"}]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"predictions": [1, 0]}`))).To(gomega.Succeed())
+
+	schemas, err := inferrer.Schemas()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(schemas.Instance.Fields).To(gomega.Equal([]monitoringv1beta1.SchemaField{
+		{Name: "country", Type: "string", Kind: monitoringv1beta1.CategoricalFeatureKind, Values: []string{"ES", "SE"}},
+	}))
+}
+
+func TestSchemaInferrerSanitizesStructFieldNames(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewSchemaInferrer(nil, nil)
+
+	g.Expect(inferrer.Add([]byte(`{"instances": [{"user": {"user-id": 1, "user.id": 2, "first name": "a", "": true}}]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"predictions": [1]}`))).To(gomega.Succeed())
+
+	schemas, err := inferrer.Schemas()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(schemas.Instance.Fields[0].Type).To(gomega.Equal("struct<_:boolean,first_name:string,user_id:long,user_id_1:long>"))
+	for _, schema := range []monitoringv1beta1.Schema{schemas.Request, schemas.Instance} {
+		for _, schemaField := range schema.Fields {
+			g.Expect(monitoringv1beta1.ValidateSparkType(schemaField.Type)).To(gomega.Succeed())
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package inference
+
+import (
+	"encoding/json"
+	"fmt"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+)
+
+// KFServing v2 protocol payload keys
+const (
+	InputsKey  = "inputs"
+	OutputsKey = "outputs"
+)
+
+// TensorInferrer infers the tensors of a model from KFServing v2 request and response payloads
+type TensorInferrer struct {
+	// Names of the features of [batch, features] input and output tensors, set when there is a single one
+	InstanceFields   []string
+	PredictionFields []string
+
+	requests, responses int
+	inputs, outputs     *tensorsType
+}
+
+// tensorsType accumulates the tensors of requests or responses, in order of appearance
+type tensorsType struct {
+	names   []string
+	tensors map[string]*monitoringv1beta1.TensorSpec
+}
+
+// v2Payload defines the fields of v2 requests and responses used for inference. Responses have no input tensors
+// and request output tensors have no datatype, so they are ignored.
+type v2Payload struct {
+	Inputs  []v2Tensor `json:"inputs"`
+	Outputs []v2Tensor `json:"outputs"`
+}
+
+type v2Tensor struct {
+	Name     string  `json:"name"`
+	Shape    []int64 `json:"shape"`
+	Datatype string  `json:"datatype"`
+}
+
+// NewTensorInferrer creates a tensor inferrer
+func NewTensorInferrer(instanceFields []string, predictionFields []string) *TensorInferrer {
+	return &TensorInferrer{
+		InstanceFields:   instanceFields,
+		PredictionFields: predictionFields,
+		inputs:           newTensorsType(),
+		outputs:          newTensorsType(),
+	}
+}
+
+func newTensorsType() *tensorsType {
+	return &tensorsType{tensors: map[string]*monitoringv1beta1.TensorSpec{}}
+}
+
+// Add adds a payload to the sample. Requests are identified by the inputs key and responses by the outputs key with datatypes.
+func (i *TensorInferrer) Add(payload []byte) error {
+	var body v2Payload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return fmt.Errorf("Invalid payload, expected a JSON object: %v", err)
+	}
+
+	switch {
+	case len(body.Inputs) > 0:
+		if err := i.inputs.add(body.Inputs); err != nil {
+			return err
+		}
+		i.requests++
+	case len(body.Outputs) > 0 && body.Outputs[0].Datatype != "":
+		if err := i.outputs.add(body.Outputs); err != nil {
+			return err
+		}
+		i.responses++
+	default:
+		return fmt.Errorf("Invalid payload, expected a KFServing v2 request or response with %s or %s", InputsKey, OutputsKey)
+	}
+	return nil
+}
+
+// Tensors returns the inferred tensors. At least one request and one response are required.
+func (i *TensorInferrer) Tensors() (*monitoringv1beta1.ModelTensorsSpec, error) {
+	if i.requests == 0 {
+		return nil, fmt.Errorf("No requests found, unable to infer the input tensors")
+	}
+	if i.responses == 0 {
+		return nil, fmt.Errorf("No responses found, unable to infer the output tensors")
+	}
+
+	inputs, err := i.inputs.list(i.InstanceFields)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to infer the input tensors: %v", err)
+	}
+	outputs, err := i.outputs.list(i.PredictionFields)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to infer the output tensors: %v", err)
+	}
+	return &monitoringv1beta1.ModelTensorsSpec{Inputs: inputs, Outputs: outputs}, nil
+}
+
+// add merges the tensors of a payload. The batch dimension and dimensions with different sizes become variable-size.
+func (t *tensorsType) add(tensors []v2Tensor) error {
+	for _, tensor := range tensors {
+		datatype := monitoringv1beta1.TensorDatatype(tensor.Datatype)
+		if tensor.Name == "" {
+			return fmt.Errorf("Invalid payload, tensor name is required")
+		}
+		if datatype.SparkType() == "" {
+			return fmt.Errorf("Invalid payload, unsupported datatype %q of tensor %s", tensor.Datatype, tensor.Name)
+		}
+
+		shape := append([]int64{}, tensor.Shape...)
+		if len(shape) > 0 {
+			shape[0] = -1
+		}
+
+		existing, ok := t.tensors[tensor.Name]
+		if !ok {
+			t.names = append(t.names, tensor.Name)
+			t.tensors[tensor.Name] = &monitoringv1beta1.TensorSpec{Name: tensor.Name, Datatype: datatype, Shape: shape}
+			continue
+		}
+		if existing.Datatype != datatype {
+			return fmt.Errorf("Invalid payload, tensor %s has datatypes %s and %s", tensor.Name, existing.Datatype, datatype)
+		}
+		if len(existing.Shape) != len(shape) {
+			return fmt.Errorf("Invalid payload, tensor %s has shapes of %d and %d dimensions", tensor.Name, len(existing.Shape), len(shape))
+		}
+		for dim := range shape {
+			if existing.Shape[dim] != shape[dim] {
+				existing.Shape[dim] = -1
+			}
+		}
+	}
+	return nil
+}
+
+func (t *tensorsType) list(features []string) ([]monitoringv1beta1.TensorSpec, error) {
+	var tensors []monitoringv1beta1.TensorSpec
+	for _, name := range t.names {
+		tensors = append(tensors, *t.tensors[name])
+	}
+	if len(features) == 0 {
+		return tensors, nil
+	}
+
+	if len(tensors) != 1 || len(tensors[0].Shape) != 2 {
+		return nil, fmt.Errorf("field names can only be given for a single [batch, features] tensor")
+	}
+	if size := tensors[0].Shape[1]; size != -1 && size != int64(len(features)) {
+		return nil, fmt.Errorf("%d field names given but tensor %s has %d features", len(features), tensors[0].Name, size)
+	}
+	tensors[0].Features = features
+	return tensors, nil
+}
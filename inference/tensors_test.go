@@ -0,0 +1,41 @@
+package inference
+
+import (
+	"testing"
+
+	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
+
+	"github.com/onsi/gomega"
+)
+
+func TestTensorInferrer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewTensorInferrer([]string{"sepal_length", "sepal_width", "petal_length", "petal_width"}, nil)
+
+	g.Expect(inferrer.Add([]byte(`{"id": "1", "inputs": [{"name": "input-0", "shape": [2, 4], "datatype": "FP32", "data": [6.8, 2.8, 4.8, 1.4, 6, 3, 5, 2]}]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"id": "2", "inputs": [{"name": "input-0", "shape": [1, 4], "datatype": "FP32", "data": [6.8, 2.8, 4.8, 1.4]}]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"model_name": "iris", "outputs": [{"name": "output-0", "shape": [2], "datatype": "INT64", "data": [1, 2]}]}`))).To(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"instances": [[1]]}`))).NotTo(gomega.Succeed())
+	g.Expect(inferrer.Add([]byte(`{"inputs": [{"name": "input-0", "shape": [1, 4], "datatype": "FP64", "data": [1, 2, 3, 4]}]}`))).NotTo(gomega.Succeed())
+
+	tensors, err := inferrer.Tensors()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(tensors).To(gomega.Equal(&monitoringv1beta1.ModelTensorsSpec{
+		Inputs: []monitoringv1beta1.TensorSpec{{
+			Name:     "input-0",
+			Datatype: monitoringv1beta1.Fp32TensorDatatype,
+			Shape:    []int64{-1, 4},
+			Features: []string{"sepal_length", "sepal_width", "petal_length", "petal_width"},
+		}},
+		Outputs: []monitoringv1beta1.TensorSpec{{Name: "output-0", Datatype: monitoringv1beta1.Int64TensorDatatype, Shape: []int64{-1}}},
+	}))
+}
+
+func TestTensorInferrerRequiresRequestsAndResponses(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inferrer := NewTensorInferrer(nil, nil)
+	g.Expect(inferrer.Add([]byte(`{"inputs": [{"name": "input-0", "shape": [1], "datatype": "BOOL", "data": [true]}]}`))).To(gomega.Succeed())
+
+	_, err := inferrer.Tensors()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
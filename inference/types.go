@@ -0,0 +1,170 @@
+package inference
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Inferred JSON value kinds, named after the Spark types they map to
+const (
+	nullKind    = "null"
+	booleanKind = "boolean"
+	longKind    = "long"
+	doubleKind  = "double"
+	stringKind  = "string"
+	arrayKind   = "array"
+	structKind  = "struct"
+)
+
+// valueType is the type inferred from one or more JSON values
+type valueType struct {
+	kind    string
+	element *valueType
+	fields  map[string]*valueType
+	// distinct values of scalar types, nil once more than maxDistinctValues are seen
+	values map[string]bool
+}
+
+// typeOf infers the type of a decoded JSON value. Numbers must be decoded as json.Number.
+func typeOf(value interface{}, maxDistinctValues int) *valueType {
+	switch v := value.(type) {
+	case nil:
+		return &valueType{kind: nullKind}
+	case bool:
+		return &valueType{kind: booleanKind, values: distinct(formatBool(v))}
+	case json.Number:
+		kind := longKind
+		if _, err := v.Int64(); err != nil {
+			kind = doubleKind
+		}
+		return &valueType{kind: kind, values: distinct(v.String())}
+	case string:
+		return &valueType{kind: stringKind, values: distinct(v)}
+	case []interface{}:
+		t := &valueType{kind: arrayKind, element: &valueType{kind: nullKind}}
+		for _, element := range v {
+			t.element = merge(t.element, typeOf(element, maxDistinctValues), maxDistinctValues)
+		}
+		return t
+	case map[string]interface{}:
+		t := &valueType{kind: structKind, fields: map[string]*valueType{}}
+		for name, field := range v {
+			t.fields[name] = typeOf(field, maxDistinctValues)
+		}
+		return t
+	}
+	return &valueType{kind: stringKind}
+}
+
+// merge returns the type compatible with both types. Numbers are widened to double and conflicting types to string.
+func merge(a *valueType, b *valueType, maxDistinctValues int) *valueType {
+	switch {
+	case a == nil || a.kind == nullKind:
+		return b
+	case b == nil || b.kind == nullKind:
+		return a
+	}
+
+	if a.kind == b.kind {
+		switch a.kind {
+		case arrayKind:
+			return &valueType{kind: arrayKind, element: merge(a.element, b.element, maxDistinctValues)}
+		case structKind:
+			fields := map[string]*valueType{}
+			for name, field := range a.fields {
+				fields[name] = field
+			}
+			for name, field := range b.fields {
+				fields[name] = merge(fields[name], field, maxDistinctValues)
+			}
+			return &valueType{kind: structKind, fields: fields}
+		}
+		return &valueType{kind: a.kind, values: union(a.values, b.values, maxDistinctValues)}
+	}
+
+	if isNumeric(a.kind) && isNumeric(b.kind) {
+		return &valueType{kind: doubleKind, values: union(a.values, b.values, maxDistinctValues)}
+	}
+	return &valueType{kind: stringKind}
+}
+
+// sparkType returns the Spark SQL data type. Types without non-null values default to string.
+func (t *valueType) sparkType() string {
+	switch t.kind {
+	case nullKind:
+		return stringKind
+	case arrayKind:
+		return "array<" + t.element.sparkType() + ">"
+	case structKind:
+		var fields []string
+		seen := map[string]bool{}
+		for _, name := range t.fieldNames() {
+			fieldName := sparkFieldName(name)
+			for suffix := 1; seen[fieldName]; suffix++ {
+				fieldName = sparkFieldName(name) + "_" + strconv.Itoa(suffix)
+			}
+			seen[fieldName] = true
+			fields = append(fields, fieldName+":"+t.fields[name].sparkType())
+		}
+		return "struct<" + strings.Join(fields, ",") + ">"
+	}
+	return t.kind
+}
+
+// sparkFieldName replaces the characters not allowed in the field names of Spark SQL struct types (letters, digits
+// and underscores) with underscores, e.g the JSON key "user-id" becomes "user_id"
+func sparkFieldName(name string) string {
+	sanitized := []byte(name)
+	for i, c := range sanitized {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			sanitized[i] = '_'
+		}
+	}
+	if len(sanitized) == 0 {
+		return "_"
+	}
+	return string(sanitized)
+}
+
+func (t *valueType) fieldNames() []string {
+	var names []string
+	for name := range t.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isNumeric(kind string) bool {
+	return kind == longKind || kind == doubleKind
+}
+
+func distinct(value string) map[string]bool {
+	return map[string]bool{value: true}
+}
+
+func union(a map[string]bool, b map[string]bool, maxDistinctValues int) map[string]bool {
+	if a == nil || b == nil {
+		return nil
+	}
+	values := map[string]bool{}
+	for value := range a {
+		values[value] = true
+	}
+	for value := range b {
+		values[value] = true
+	}
+	if len(values) > maxDistinctValues {
+		return nil
+	}
+	return values
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,69 @@
+package kafka
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Client settings
+var (
+	// Topic deletion and partition management require brokers 1.0.0 or later
+	AdminKafkaVersion = sarama.V1_0_0_0
+	AdminClientID     = "model-monitoring-operator"
+	// Unreachable brokers fail fast instead of holding the reconcile loop for the Sarama defaults (30s per
+	// connection attempt and 3 metadata retries)
+	DialTimeout          = 5 * time.Second
+	ReadTimeout          = 10 * time.Second
+	MetadataRetryMax     = 1
+	MetadataRetryBackoff = 100 * time.Millisecond
+)
+
+// NewClusterAdminFunc creates a Kafka cluster admin for a comma-separated list of brokers
+type NewClusterAdminFunc func(brokers string, security *SecurityConfig) (sarama.ClusterAdmin, error)
+
+// NewClusterAdmin creates a Kafka cluster admin for a comma-separated list of brokers.
+// The security config is optional, a plaintext connection is used if nil.
+func NewClusterAdmin(brokers string, security *SecurityConfig) (sarama.ClusterAdmin, error) {
+	config, err := newConfig(security)
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewClusterAdmin(strings.Split(brokers, ","), config)
+}
+
+// NewConsumer creates a Kafka consumer for a comma-separated list of brokers.
+// The security config is optional, a plaintext connection is used if nil.
+func NewConsumer(brokers string, security *SecurityConfig) (sarama.Consumer, error) {
+	config, err := newConfig(security)
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewConsumer(strings.Split(brokers, ","), config)
+}
+
+// NewClient creates a Kafka client for a comma-separated list of brokers.
+// The security config is optional, a plaintext connection is used if nil.
+func NewClient(brokers string, security *SecurityConfig) (sarama.Client, error) {
+	config, err := newConfig(security)
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewClient(strings.Split(brokers, ","), config)
+}
+
+func newConfig(security *SecurityConfig) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	config.Version = AdminKafkaVersion
+	config.ClientID = AdminClientID
+	config.Net.DialTimeout = DialTimeout
+	config.Net.ReadTimeout = ReadTimeout
+	config.Net.WriteTimeout = ReadTimeout
+	config.Metadata.Retry.Max = MetadataRetryMax
+	config.Metadata.Retry.Backoff = MetadataRetryBackoff
+	if err := security.apply(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// ReadMessages reads up to n messages from the beginning of all the partitions of a topic.
+// It returns the messages read when the timeout expires before reading n messages.
+func ReadMessages(consumer sarama.Consumer, topic string, n int, timeout time.Duration) ([]*sarama.ConsumerMessage, error) {
+	partitions, err := consumer.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitionConsumers []sarama.PartitionConsumer
+	defer func() {
+		for _, partitionConsumer := range partitionConsumers {
+			partitionConsumer.AsyncClose()
+		}
+	}()
+
+	messages := make(chan *sarama.ConsumerMessage)
+	done := make(chan struct{})
+	defer close(done)
+	for _, partition := range partitions {
+		partitionConsumer, err := consumer.ConsumePartition(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+		partitionConsumers = append(partitionConsumers, partitionConsumer)
+		go func(partitionConsumer sarama.PartitionConsumer) {
+			for message := range partitionConsumer.Messages() {
+				select {
+				case messages <- message:
+				case <-done:
+					return
+				}
+			}
+		}(partitionConsumer)
+	}
+
+	var read []*sarama.ConsumerMessage
+	deadline := time.After(timeout)
+	for len(read) < n {
+		select {
+		case message := <-messages:
+			read = append(read, message)
+		case <-deadline:
+			return read, nil
+		}
+	}
+	return read, nil
+}
@@ -0,0 +1,37 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/onsi/gomega"
+)
+
+func TestReadMessages(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"topic": {0, 1}})
+	for _, partition := range []int32{0, 1} {
+		partitionConsumer := consumer.ExpectConsumePartition("topic", partition, sarama.OffsetOldest)
+		partitionConsumer.YieldMessage(&sarama.ConsumerMessage{Value: []byte("message")})
+		partitionConsumer.YieldMessage(&sarama.ConsumerMessage{Value: []byte("message")})
+	}
+
+	messages, err := ReadMessages(consumer, "topic", 3, time.Second)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(messages).To(gomega.HaveLen(3))
+}
+
+func TestReadMessagesTimeout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"topic": {0}})
+	consumer.ExpectConsumePartition("topic", 0, sarama.OffsetOldest).
+		YieldMessage(&sarama.ConsumerMessage{Value: []byte("message")})
+
+	messages, err := ReadMessages(consumer, "topic", 10, 100*time.Millisecond)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(messages).To(gomega.HaveLen(1))
+}
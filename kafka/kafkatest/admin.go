@@ -0,0 +1,118 @@
+// Package kafkatest provides an in-memory Kafka cluster admin for testing
+package kafkatest
+
+import (
+	"sync"
+
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+
+	"github.com/Shopify/sarama"
+)
+
+// ClusterAdmin is an in-memory sarama.ClusterAdmin keeping track of topics.
+// Only the topic management methods are implemented, calling any other method panics.
+type ClusterAdmin struct {
+	sarama.ClusterAdmin
+
+	mu     sync.Mutex
+	topics map[string]sarama.TopicDetail
+}
+
+// NewClusterAdmin creates an in-memory cluster admin with no topics
+func NewClusterAdmin() *ClusterAdmin {
+	return &ClusterAdmin{topics: map[string]sarama.TopicDetail{}}
+}
+
+// NewClusterAdminFunc returns a constructor always returning the given cluster admin, regardless of the brokers
+func NewClusterAdminFunc(admin *ClusterAdmin) kafka.NewClusterAdminFunc {
+	return func(brokers string, security *kafka.SecurityConfig) (sarama.ClusterAdmin, error) {
+		return admin, nil
+	}
+}
+
+// Topic returns the detail of a topic and whether it exists
+func (a *ClusterAdmin) Topic(name string) (sarama.TopicDetail, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	detail, ok := a.topics[name]
+	return detail, ok
+}
+
+// CreateTopic creates a topic
+func (a *ClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.topics[topic]; ok {
+		return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+	}
+	if detail.NumPartitions <= 0 {
+		return &sarama.TopicError{Err: sarama.ErrInvalidPartitions}
+	}
+	if detail.ReplicationFactor <= 0 {
+		return &sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor}
+	}
+	if !validateOnly {
+		a.topics[topic] = *detail
+	}
+	return nil
+}
+
+// DescribeTopics returns the metadata of the given topics
+func (a *ClusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var metadata []*sarama.TopicMetadata
+	for _, topic := range topics {
+		detail, ok := a.topics[topic]
+		if !ok {
+			metadata = append(metadata, &sarama.TopicMetadata{Name: topic, Err: sarama.ErrUnknownTopicOrPartition})
+			continue
+		}
+
+		replicas := make([]int32, detail.ReplicationFactor)
+		for i := range replicas {
+			replicas[i] = int32(i)
+		}
+		partitions := make([]*sarama.PartitionMetadata, detail.NumPartitions)
+		for i := range partitions {
+			partitions[i] = &sarama.PartitionMetadata{ID: int32(i), Replicas: replicas, Isr: replicas}
+		}
+		metadata = append(metadata, &sarama.TopicMetadata{Name: topic, Partitions: partitions})
+	}
+	return metadata, nil
+}
+
+// CreatePartitions increases the number of partitions of a topic
+func (a *ClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	detail, ok := a.topics[topic]
+	if !ok {
+		return &sarama.TopicPartitionError{Err: sarama.ErrUnknownTopicOrPartition}
+	}
+	if count <= detail.NumPartitions {
+		return &sarama.TopicPartitionError{Err: sarama.ErrInvalidPartitions}
+	}
+	if !validateOnly {
+		detail.NumPartitions = count
+		a.topics[topic] = detail
+	}
+	return nil
+}
+
+// DeleteTopic deletes a topic
+func (a *ClusterAdmin) DeleteTopic(topic string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.topics[topic]; !ok {
+		return sarama.ErrUnknownTopicOrPartition
+	}
+	delete(a.topics, topic)
+	return nil
+}
+
+// Close is a no-op, the admin is reused across constructor calls
+func (a *ClusterAdmin) Close() error {
+	return nil
+}
@@ -0,0 +1,33 @@
+package kafka
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/xdg/scram"
+)
+
+var (
+	sha256Hash scram.HashGeneratorFcn = sha256.New
+	sha512Hash scram.HashGeneratorFcn = sha512.New
+)
+
+// scramClient adapts a SCRAM client conversation (RFC 5802) to the Sarama SCRAM client interface
+type scramClient struct {
+	*scram.ClientConversation
+	hash scram.HashGeneratorFcn
+}
+
+func newSCRAMClient(hash scram.HashGeneratorFcn) *scramClient {
+	return &scramClient{hash: hash}
+}
+
+// Begin prepares the client for a new exchange
+func (c *scramClient) Begin(username, password, authzID string) error {
+	client, err := c.hash.NewClient(username, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.ClientConversation = client.NewConversation()
+	return nil
+}
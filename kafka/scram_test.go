@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+// newTestSCRAMClient begins an exchange with the client nonce of the RFC 7677 test vector
+func newTestSCRAMClient(g *gomega.GomegaWithT) *scramClient {
+	client := newSCRAMClient(sha256Hash)
+	g.Expect(client.Begin("user", "pencil", "")).To(gomega.Succeed())
+	scram, err := sha256Hash.NewClient("user", "pencil", "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	client.ClientConversation = scram.WithNonceGenerator(func() string { return "rOprNGfwEbeRWgbNEkqO" }).NewConversation()
+	return client
+}
+
+// Test vector from RFC 7677
+func TestSCRAMClientSHA256(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	client := newTestSCRAMClient(g)
+
+	clientFirst, err := client.Step("")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(clientFirst).To(gomega.Equal("n,,n=user,r=rOprNGfwEbeRWgbNEkqO"))
+
+	clientFinal, err := client.Step("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(clientFinal).To(gomega.Equal("c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ="))
+
+	_, err = client.Step("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(client.Done()).To(gomega.BeTrue())
+}
+
+func TestSCRAMClientInvalidServerSignature(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	client := newTestSCRAMClient(g)
+
+	_, _ = client.Step("")
+	_, _ = client.Step("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	_, err := client.Step("v=AAAA")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
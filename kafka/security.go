@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// SASL mechanisms
+const (
+	SASLMechanismPlain       = sarama.SASLTypePlaintext
+	SASLMechanismScramSHA256 = sarama.SASLTypeSCRAMSHA256
+	SASLMechanismScramSHA512 = sarama.SASLTypeSCRAMSHA512
+)
+
+// SecurityConfig defines the credentials to connect to a secured Kafka cluster, resolved by the caller
+type SecurityConfig struct {
+	// TLS is enabled when not nil
+	TLS *tls.Config
+	// SASL is enabled when the mechanism is not empty
+	SASLMechanism string
+	SASLUsername  string
+	SASLPassword  string
+}
+
+// NewTLSConfig creates a TLS config from PEM encoded certificates. The CA defaults to the system CAs if empty,
+// and the client certificate is only set if both cert and key are provided.
+func NewTLSConfig(caCert, cert, key []byte) (*tls.Config, error) {
+	config := &tls.Config{}
+	if len(caCert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("Unable to parse Kafka CA certificate")
+		}
+		config.RootCAs = pool
+	}
+	if len(cert) > 0 && len(key) > 0 {
+		certificate, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse Kafka client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+func (s *SecurityConfig) apply(config *sarama.Config) error {
+	if s == nil {
+		return nil
+	}
+	if s.TLS != nil {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = s.TLS
+	}
+	if s.SASLMechanism != "" {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Handshake = true
+		config.Net.SASL.User = s.SASLUsername
+		config.Net.SASL.Password = s.SASLPassword
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(s.SASLMechanism)
+
+		switch s.SASLMechanism {
+		case SASLMechanismPlain:
+		case SASLMechanismScramSHA256:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return newSCRAMClient(sha256Hash) }
+		case SASLMechanismScramSHA512:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return newSCRAMClient(sha512Hash) }
+		default:
+			return fmt.Errorf("Unsupported SASL mechanism %q", s.SASLMechanism)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"github.com/Shopify/sarama"
+)
+
+// Topic defines the observed state of a Kafka topic
+type Topic struct {
+	Name              string
+	Partitions        int32
+	ReplicationFactor int16
+}
+
+// DescribeTopic returns the state of a topic, or nil if it doesn't exist
+func DescribeTopic(admin sarama.ClusterAdmin, name string) (*Topic, error) {
+	metadata, err := admin.DescribeTopics([]string{name})
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) == 0 || metadata[0].Err == sarama.ErrUnknownTopicOrPartition {
+		return nil, nil
+	}
+	if metadata[0].Err != sarama.ErrNoError {
+		return nil, metadata[0].Err
+	}
+
+	topic := &Topic{
+		Name:       name,
+		Partitions: int32(len(metadata[0].Partitions)),
+	}
+	if len(metadata[0].Partitions) > 0 {
+		topic.ReplicationFactor = int16(len(metadata[0].Partitions[0].Replicas))
+	}
+	return topic, nil
+}
+
+// CreateTopic creates a topic, ignoring it if it already exists
+func CreateTopic(admin sarama.ClusterAdmin, name string, partitions int32, replicationFactor int16) error {
+	detail := &sarama.TopicDetail{
+		NumPartitions:     partitions,
+		ReplicationFactor: replicationFactor,
+	}
+	if err := admin.CreateTopic(name, detail, false); err != nil && !isKafkaError(err, sarama.ErrTopicAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// IncreasePartitions grows the number of partitions of a topic. Kafka doesn't support decreasing them.
+func IncreasePartitions(admin sarama.ClusterAdmin, name string, partitions int32) error {
+	return admin.CreatePartitions(name, partitions, nil, false)
+}
+
+// DeleteTopic deletes a topic, ignoring it if it doesn't exist
+func DeleteTopic(admin sarama.ClusterAdmin, name string) error {
+	if err := admin.DeleteTopic(name); err != nil && !isKafkaError(err, sarama.ErrUnknownTopicOrPartition) {
+		return err
+	}
+	return nil
+}
+
+func isKafkaError(err error, kerr sarama.KError) bool {
+	switch e := err.(type) {
+	case sarama.KError:
+		return e == kerr
+	case *sarama.TopicError:
+		return e.Err == kerr
+	case *sarama.TopicPartitionError:
+		return e.Err == kerr
+	}
+	return false
+}
@@ -18,19 +18,25 @@ package main
 
 import (
 	"flag"
+	"net/http"
 	"os"
+	"strings"
 
 	monitoringv1beta1 "github.com/javierdlrm/model-monitoring-operator/api/v1beta1"
 	"github.com/javierdlrm/model-monitoring-operator/constants"
 	"github.com/javierdlrm/model-monitoring-operator/controllers"
+	"github.com/javierdlrm/model-monitoring-operator/kafka"
+	"github.com/javierdlrm/model-monitoring-operator/utils"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	sparkv1beta2 "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
+	kfservingv1alpha2 "github.com/kubeflow/kfserving/pkg/apis/serving/v1alpha2"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	// +kubebuilder:scaffold:imports
 )
@@ -46,6 +52,7 @@ func init() {
 	_ = monitoringv1beta1.AddToScheme(scheme)
 	_ = knservingv1.AddToScheme(scheme)
 	_ = sparkv1beta2.AddToScheme(scheme)
+	_ = kfservingv1alpha2.AddToScheme(scheme)
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -53,35 +60,81 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enableWebhooks bool
+	var namespace string
+	var configMapName string
+	var watchNamespaces string
+	var enableSinkProbes bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"Enable admission webhooks for ModelMonitor resources. "+
+			"Webhooks require serving certificates, disable them when running the manager locally.")
+	flag.StringVar(&namespace, "namespace", utils.GetEnvOrDefault(constants.ModelMonitoringNamespaceEnvVar, constants.ModelMonitoringNamespace),
+		"The namespace of the operator ConfigMap. Defaults to the "+constants.ModelMonitoringNamespaceEnvVar+" env var, if set.")
+	flag.StringVar(&configMapName, "config-map-name", utils.GetEnvOrDefault(constants.ModelMonitorConfigMapNameEnvVar, constants.ModelMonitorConfigMapName),
+		"The name of the operator ConfigMap. A ConfigMap with the same name in a ModelMonitor namespace takes precedence. "+
+			"Defaults to the "+constants.ModelMonitorConfigMapNameEnvVar+" env var, if set.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", os.Getenv(constants.WatchNamespacesEnvVar),
+		"Comma-separated namespaces of the ModelMonitors reconciled by this installation, all namespaces if empty. "+
+			"Defaults to the "+constants.WatchNamespacesEnvVar+" env var, if set.")
+	flag.BoolVar(&enableSinkProbes, "enable-sink-probes", false,
+		"Probe the S3 buckets and Pushgateways of the analysis sinks from the operator. "+
+			"The URLs are set by ModelMonitor authors, only enable it if they may send requests from the operator network.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	options := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "15f4751e.hops.io",
-	})
+	}
+	if namespaces := splitNamespaces(watchNamespaces); len(namespaces) > 0 {
+		// The operator ConfigMap is watched too, even if no ModelMonitors are reconciled in its namespace
+		if !utils.ContainsString(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+		setupLog.Info("Watching namespaces", "namespaces", namespaces)
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	var sinkProbeClient *http.Client
+	if enableSinkProbes {
+		sinkProbeClient = &http.Client{Timeout: constants.SinkProbeTimeout}
+	}
+
 	if err = (&controllers.ModelMonitorReconciler{
 		Client:   mgr.GetClient(),
 		Log:      ctrl.Log.WithName("controllers").WithName("ModelMonitor"),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor(constants.ModelMonitorControllerName),
+
+		APIReader:            mgr.GetAPIReader(),
+		ConfigMapNamespace:   namespace,
+		ConfigMapName:        configMapName,
+		NewKafkaClusterAdmin: kafka.NewClusterAdmin,
+		SinkProbeClient:      sinkProbeClient,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ModelMonitor")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err = (&monitoringv1beta1.ModelMonitor{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ModelMonitor")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("Starting manager")
@@ -90,3 +143,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitNamespaces returns the namespaces of a comma-separated list, ignoring blanks
+func splitNamespaces(list string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(list, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
@@ -0,0 +1,65 @@
+// Package sinks checks the availability of the analysis sinks written by the Monitoring job
+package sinks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// S3BucketURL returns the URL of a bucket. AWS S3 is used if the endpoint is empty.
+func S3BucketURL(endpoint string, region string, bucket string, pathStyle bool) (string, error) {
+	if endpoint == "" {
+		endpoint = "https://s3.amazonaws.com"
+		if region != "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if pathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + bucket
+	} else {
+		u.Host = bucket + "." + u.Host
+	}
+	return u.String(), nil
+}
+
+// ProbeS3Bucket checks that a bucket exists. The request is not signed, so buckets denying access are considered available.
+func ProbeS3Bucket(client *http.Client, endpoint string, region string, bucket string, pathStyle bool) error {
+	bucketURL, err := S3BucketURL(endpoint, region, bucket, pathStyle)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Head(bucketURL)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("bucket %s not found", bucket)
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, bucketURL)
+	}
+	return nil
+}
+
+// ProbePushgateway checks that a Prometheus Pushgateway is ready
+func ProbePushgateway(client *http.Client, pushgatewayURL string) error {
+	readyURL := strings.TrimSuffix(pushgatewayURL, "/") + "/-/ready"
+	resp, err := client.Get(readyURL)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, readyURL)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Package sinkstest provides in-process stand-ins of the analysis sinks for testing
+package sinkstest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// ObjectStore is a minimal in-memory S3-compatible server, supporting path-style bucket and object requests
+type ObjectStore struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+// NewObjectStore starts an object store with the given buckets
+func NewObjectStore(buckets ...string) *ObjectStore {
+	s := &ObjectStore{buckets: map[string]map[string][]byte{}}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = map[string][]byte{}
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Object returns the content of an object and whether it exists
+func (s *ObjectStore) Object(bucket string, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.buckets[bucket][key]
+	return object, ok
+}
+
+func (s *ObjectStore) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	bucket, key := parts[0], ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+
+	objects, ok := s.buckets[bucket]
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead, http.MethodGet:
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			if !ok {
+				s.buckets[bucket] = map[string][]byte{}
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		object, ok := objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write(object)
+		}
+	case http.MethodPut:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		objects[key] = body
+	case http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,55 @@
+package sinkstest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Pushgateway is an in-memory Prometheus Pushgateway recording the pushed metrics by job
+type Pushgateway struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	pushes map[string][]string
+}
+
+// NewPushgateway starts a Pushgateway
+func NewPushgateway() *Pushgateway {
+	p := &Pushgateway{pushes: map[string][]string{}}
+	p.Server = httptest.NewServer(http.HandlerFunc(p.handle))
+	return p
+}
+
+// Pushes returns the bodies pushed for a given job
+func (p *Pushgateway) Pushes(job string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.pushes[job]...)
+}
+
+func (p *Pushgateway) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/-/ready" || r.URL.Path == "/-/healthy" {
+		return
+	}
+
+	// Pushes are sent to /metrics/job/<job>{/<label>/<value>}
+	const prefix = "/metrics/job/"
+	if !strings.HasPrefix(r.URL.Path, prefix) || (r.Method != http.MethodPut && r.Method != http.MethodPost) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	job := strings.SplitN(strings.TrimPrefix(r.URL.Path, prefix), "/", 2)[0]
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pushes[job] = append(p.pushes[job], string(body))
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,46 @@
+package sinkstest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Webhook is an HTTP server recording the bodies of the requests received
+type Webhook struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewWebhook starts a webhook receiver
+func NewWebhook() *Webhook {
+	wh := &Webhook{}
+	wh.Server = httptest.NewServer(http.HandlerFunc(wh.handle))
+	return wh
+}
+
+// Requests returns the bodies received
+func (wh *Webhook) Requests() []string {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	return append([]string(nil), wh.requests...)
+}
+
+func (wh *Webhook) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	wh.requests = append(wh.requests, string(body))
+}
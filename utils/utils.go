@@ -1,5 +1,36 @@
 package utils
 
+import "os"
+
+// GetEnvOrDefault returns the value of an environment variable or a default value if it is not set
+func GetEnvOrDefault(key string, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// ContainsString returns if a slice contains a given value
+func ContainsString(slice []string, value string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveString returns a copy of a slice without the occurrences of a given value
+func RemoveString(slice []string, value string) []string {
+	result := []string{}
+	for _, item := range slice {
+		if item != value {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // String32 convert an int32 into string
 func String32(n int32) string {
 	buf := [11]byte{}